@@ -0,0 +1,8 @@
+data "sakuracloud_archives" "foobar" {
+  filter {
+    tags = ["os-linux"]
+  }
+  sort_by    = "created_at"
+  sort_order = "desc"
+  limit      = 5
+}
@@ -0,0 +1,32 @@
+data "sakuracloud_service_class" "server" {
+  service_class_path = "cloud/plan/1"
+}
+
+data "sakuracloud_cost_estimate" "foobar" {
+  item {
+    service_class_path = data.sakuracloud_service_class.server.service_class_path
+    quantity           = 2
+  }
+  item {
+    server {
+      core   = 2
+      memory = 4
+    }
+  }
+  item {
+    disk {
+      plan = "ssd"
+      size = 40
+    }
+  }
+  item {
+    appliance {
+      type = "proxylb"
+      plan = 100
+    }
+  }
+}
+
+output "total_monthly_price" {
+  value = data.sakuracloud_cost_estimate.foobar.total_monthly_price
+}
\ No newline at end of file
@@ -0,0 +1,3 @@
+data "sakuracloud_database_monitor" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+}
@@ -0,0 +1,5 @@
+data "sakuracloud_databases" "foobar" {
+  filter {
+    tags = ["foobar"]
+  }
+}
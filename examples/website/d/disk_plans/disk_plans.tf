@@ -0,0 +1,5 @@
+data "sakuracloud_disk_plans" "foobar" {
+  plan           = "ssd"
+  size           = 20
+  available_only = true
+}
@@ -0,0 +1,5 @@
+data "sakuracloud_disks" "foobar" {
+  filter {
+    tags = ["foobar"]
+  }
+}
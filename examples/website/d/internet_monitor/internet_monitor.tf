@@ -0,0 +1,6 @@
+data "sakuracloud_internet_monitor" "foobar" {
+  internet_id = sakuracloud_internet.foobar.id
+  start       = "2021-06-01T00:00:00+09:00"
+  end         = "2021-07-01T00:00:00+09:00"
+  percentile  = 95
+}
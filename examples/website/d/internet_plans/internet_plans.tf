@@ -0,0 +1,3 @@
+data "sakuracloud_internet_plans" "foobar" {
+  available_only = true
+}
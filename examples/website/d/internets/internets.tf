@@ -0,0 +1,5 @@
+data "sakuracloud_internets" "foobar" {
+  filter {
+    tags = ["foobar"]
+  }
+}
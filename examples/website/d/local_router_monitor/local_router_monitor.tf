@@ -0,0 +1,3 @@
+data "sakuracloud_local_router_monitor" "foobar" {
+  local_router_id = sakuracloud_local_router.foobar.id
+}
@@ -0,0 +1,3 @@
+data "sakuracloud_nfs_monitor" "foobar" {
+  nfs_id = sakuracloud_nfs.foobar.id
+}
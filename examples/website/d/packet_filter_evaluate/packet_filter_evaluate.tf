@@ -0,0 +1,7 @@
+data "sakuracloud_packet_filter_evaluate" "foobar" {
+  packet_filter_id = "123456789012"
+  protocol         = "tcp"
+  source_address   = "192.0.2.1"
+  source_port      = 40000
+  destination_port = 22
+}
@@ -0,0 +1,3 @@
+data "sakuracloud_private_host_plans" "foobar" {
+  class = "dynamic"
+}
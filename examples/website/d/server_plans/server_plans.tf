@@ -0,0 +1,5 @@
+data "sakuracloud_server_plans" "foobar" {
+  core       = 2
+  memory     = 4
+  commitment = "standard"
+}
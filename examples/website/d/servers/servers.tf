@@ -0,0 +1,22 @@
+data "sakuracloud_servers" "web" {
+  filter {
+    tags = ["role=web"]
+  }
+  sort_by = "name"
+}
+
+# servers tagged "role=web" or "role=api" but not "maintenance"
+data "sakuracloud_servers" "app" {
+  filter {
+    condition {
+      name   = "Tags.Name"
+      values = ["role=web", "role=api"]
+      any    = true
+    }
+    condition {
+      name   = "Tags.Name"
+      values = ["maintenance"]
+      not    = true
+    }
+  }
+}
@@ -0,0 +1,3 @@
+data "sakuracloud_service_class" "foobar" {
+  service_class_path = "cloud/plan/1"
+}
@@ -0,0 +1,3 @@
+data "sakuracloud_switch_topology" "foobar" {
+  switch_id = "123456789012"
+}
@@ -0,0 +1,5 @@
+data "sakuracloud_switches" "foobar" {
+  filter {
+    tags = ["foobar"]
+  }
+}
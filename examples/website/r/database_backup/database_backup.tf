@@ -0,0 +1,3 @@
+resource "sakuracloud_database_backup" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+}
@@ -0,0 +1,8 @@
+resource "sakuracloud_database_restore" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+  backup_id   = sakuracloud_database_backup.foobar.id
+
+  triggers = {
+    restored_by = "your-name"
+  }
+}
@@ -0,0 +1,16 @@
+data "sakuracloud_archive" "ubuntu" {
+  os_type = "ubuntu2004"
+}
+
+resource "sakuracloud_disk" "foobar" {
+  name              = "foobar"
+  source_archive_id = data.sakuracloud_archive.ubuntu.id
+}
+
+resource "sakuracloud_disk_edit" "foobar" {
+  disk_id         = sakuracloud_disk.foobar.id
+  hostname        = "your-host-name"
+  password        = "your-password"
+  ssh_keys        = ["ssh-rsa xxxxx"]
+  disable_pw_auth = true
+}
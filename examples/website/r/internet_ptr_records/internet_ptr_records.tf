@@ -0,0 +1,16 @@
+resource "sakuracloud_internet" "foobar" {
+  name = "foobar"
+}
+
+resource "sakuracloud_internet_ptr_records" "foobar" {
+  internet_id = sakuracloud_internet.foobar.id
+
+  record {
+    ip_address = sakuracloud_internet.foobar.ip_addresses[0]
+    hostname   = "www1.example.com"
+  }
+  record {
+    ip_address = sakuracloud_internet.foobar.ip_addresses[1]
+    hostname   = "www2.example.com"
+  }
+}
@@ -0,0 +1,16 @@
+resource "sakuracloud_switch" "foobar" {
+  name = "foobar"
+}
+
+resource "sakuracloud_ip_address_allocation" "foobar" {
+  switch_id = sakuracloud_switch.foobar.id
+  cidr      = "192.168.0.0/24"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "foobar"
+  network_interface {
+    upstream        = sakuracloud_switch.foobar.id
+    user_ip_address = sakuracloud_ip_address_allocation.foobar.ip_address
+  }
+}
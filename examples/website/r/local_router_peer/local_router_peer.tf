@@ -0,0 +1,16 @@
+# both of the LocalRouters are readable: the peering is set up on both sides
+resource "sakuracloud_local_router_peer" "foobar" {
+  local_router_id = sakuracloud_local_router.foobar1.id
+  peer_id         = sakuracloud_local_router.foobar2.id
+  description     = "description"
+}
+
+# the peer LocalRouter is owned by another account: the peering is set up on this side only
+resource "sakuracloud_local_router_peer" "other" {
+  local_router_id = sakuracloud_local_router.foobar1.id
+  peer_id         = var.other_local_router_id
+  secret_key      = var.other_local_router_secret_key
+}
+
+variable "other_local_router_id" {}
+variable "other_local_router_secret_key" {}
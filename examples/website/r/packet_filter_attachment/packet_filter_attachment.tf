@@ -0,0 +1,20 @@
+resource "sakuracloud_packet_filter" "foobar" {
+  name = "foobar"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "foobar"
+  network_interface {
+    upstream = "shared"
+  }
+
+  lifecycle {
+    ignore_changes = [network_interface[0].packet_filter_id]
+  }
+}
+
+resource "sakuracloud_packet_filter_attachment" "foobar" {
+  server_id        = sakuracloud_server.foobar.id
+  nic_index        = 0
+  packet_filter_id = sakuracloud_packet_filter.foobar.id
+}
@@ -0,0 +1,16 @@
+resource "sakuracloud_switch" "foobar" {
+  name = "foobar"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "foobar"
+  network_interface {
+    upstream = "shared"
+  }
+}
+
+resource "sakuracloud_server_network_interface" "foobar" {
+  server_id       = sakuracloud_server.foobar.id
+  upstream        = sakuracloud_switch.foobar.id
+  user_ip_address = "192.168.0.11"
+}
@@ -0,0 +1,25 @@
+resource "sakuracloud_bridge" "foobar" {
+  name = "foobar"
+}
+
+resource "sakuracloud_switch" "is1a" {
+  name = "foobar"
+  zone = "is1a"
+}
+
+resource "sakuracloud_switch" "tk1a" {
+  name = "foobar"
+  zone = "tk1a"
+}
+
+resource "sakuracloud_switch_bridge_connection" "is1a" {
+  switch_id = sakuracloud_switch.is1a.id
+  bridge_id = sakuracloud_bridge.foobar.id
+  zone      = "is1a"
+}
+
+resource "sakuracloud_switch_bridge_connection" "tk1a" {
+  switch_id = sakuracloud_switch.tk1a.id
+  bridge_id = sakuracloud_bridge.foobar.id
+  zone      = "tk1a"
+}
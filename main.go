@@ -41,4 +41,7 @@ func main() {
 		plugin.Serve(&plugin.ServeOpts{
 			ProviderFunc: sakuracloud.Provider})
 	}
+
+	// プラグインのサーバー停止後に未送信のスパンを送信する
+	sakuracloud.ShutdownTracerProviders()
 }
@@ -15,6 +15,7 @@
 package sakuracloud
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -30,6 +31,10 @@ import (
 	"github.com/sacloud/libsacloud/v2/helper/query"
 	"github.com/sacloud/libsacloud/v2/sacloud"
 	"github.com/sacloud/libsacloud/v2/sacloud/profile"
+	sacloudotel "github.com/sacloud/libsacloud/v2/sacloud/trace/otel"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 const (
@@ -53,8 +58,11 @@ type Config struct {
 	AccessTokenSecret   string
 	Zone                string
 	Zones               []string
+	MultiZone           bool
 	DefaultZone         string
 	TraceMode           string
+	TraceFormat         string
+	TraceFilePath       string
 	FakeMode            string
 	FakeStorePath       string
 	AcceptLanguage      string
@@ -64,8 +72,16 @@ type Config struct {
 	RetryWaitMax        int
 	APIRequestTimeout   int
 	APIRequestRateLimit int
+	DefaultTags         []string
+	IgnoreTagKeys       []string
+	IgnoreTagPrefixes   []string
+	OTelExporter        string
+	OTelEndpoint        string
+	OTelInsecure        bool
+	OTelFilePath        string
 
 	terraformVersion string
+	sensitiveKeys    []string // トレースログでマスクするスキーマのキー
 }
 
 // APIClient for SakuraCloud API
@@ -73,10 +89,70 @@ type APIClient struct {
 	sacloud.APICaller
 	defaultZone                      string // 各リソースでzone未指定の場合に利用するゾーン。sacloud.APIDefaultZoneとは別物。
 	zones                            []string
+	multiZone                        bool       // trueの場合、複数件を返すデータソースでzone未指定時にzones全てを検索する
+	defaultTags                      types.Tags // 各リソースへ付与するタグ
+	ignoreTags                       *ignoreTags
 	deletionWaiterTimeout            time.Duration
 	deletionWaiterPollingInterval    time.Duration
 	databaseWaitAfterCreateDuration  time.Duration
 	vpcRouterWaitAfterCreateDuration time.Duration
+	tracerProvider                   *sdktrace.TracerProvider // nilの場合はトレースしない
+}
+
+// ignoreTags 外部ツールなどで管理され、Terraformでは差分として扱わないタグ
+type ignoreTags struct {
+	keys        []string
+	keyPrefixes []string
+}
+
+// isIgnored tagが無視対象か判定する
+//
+// keysは"key"または"key=value"形式のタグのキー部分と一致するか、keyPrefixesはタグ全体の前方一致で判定する
+func (t *ignoreTags) isIgnored(tag string) bool {
+	if t == nil {
+		return false
+	}
+	for _, key := range t.keys {
+		if tag == key || strings.HasPrefix(tag, key+"=") {
+			return true
+		}
+	}
+	for _, prefix := range t.keyPrefixes {
+		if strings.HasPrefix(tag, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateZone zoneがプロバイダーのzonesに含まれているか検証する
+func (c *APIClient) validateZone(key, zone string) error {
+	for _, z := range c.zones {
+		if z == zone {
+			return nil
+		}
+	}
+	return fmt.Errorf("expected %s to be one of %q, got %s", key, c.zones, zone)
+}
+
+// validateZonesWithAPI ZoneAPIから取得したゾーンにzonesが全て含まれているか検証する
+func validateZonesWithAPI(ctx context.Context, caller sacloud.APICaller, zones []string) error {
+	res, err := sacloud.NewZoneOp(caller).Find(ctx, &sacloud.FindCondition{})
+	if err != nil {
+		return fmt.Errorf("could not find SakuraCloud Zone resources: %s", err)
+	}
+	available := make(map[string]bool)
+	for _, z := range res.Zones {
+		available[z.Name] = true
+	}
+
+	var errs error
+	for _, zone := range zones {
+		if !available[zone] {
+			errs = multierror.Append(errs, fmt.Errorf("zone %q is not available on SakuraCloud", zone))
+		}
+	}
+	return errs
 }
 
 func (c *APIClient) checkReferencedOption() query.CheckReferencedOption {
@@ -187,6 +263,20 @@ func (c *Config) NewClient() (*APIClient, error) {
 		case traceHTTP:
 			enableAPITrace = false
 		}
+
+		if err := c.setupTraceLog(); err != nil {
+			return nil, err
+		}
+	}
+
+	tracerProvider, err := c.initTracerProvider()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := http.DefaultClient
+	if tracerProvider != nil {
+		// Transportをプロバイダー固有のTracerProviderを利用するものへ差し替えるため、http.DefaultClientとは別のクライアントを利用する
+		httpClient = &http.Client{}
 	}
 
 	caller := api.NewCaller(&api.CallerOptions{
@@ -195,7 +285,7 @@ func (c *Config) NewClient() (*APIClient, error) {
 		APIRootURL:           c.APIRootURL,
 		DefaultZone:          c.DefaultZone,
 		AcceptLanguage:       c.AcceptLanguage,
-		HTTPClient:           http.DefaultClient,
+		HTTPClient:           httpClient,
 		HTTPRequestTimeout:   c.APIRequestTimeout,
 		HTTPRequestRateLimit: c.APIRequestRateLimit,
 		RetryMax:             c.RetryMax,
@@ -204,12 +294,21 @@ func (c *Config) NewClient() (*APIClient, error) {
 		UserAgent:            ua,
 		TraceAPI:             enableAPITrace,
 		TraceHTTP:            enableHTTPTrace,
-		OpenTelemetry:        false,
-		OpenTelemetryOptions: nil,
+		OpenTelemetry:        false, // HTTPのスパンをグローバルなTracerProviderで記録するため利用せず、後続の処理で初期化する
 		FakeMode:             c.FakeMode != "",
 		FakeStorePath:        c.FakeStorePath,
 	})
 
+	if tracerProvider != nil {
+		// note: libsacloudのAPIのトレースはプロセス内で1度だけ初期化されるため、最初に設定されたプロバイダーのTracerProviderが利用される
+		sacloudotel.Initialize(sacloudotel.WithTracerProvider(tracerProvider))
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		httpClient.Transport = otelhttp.NewTransport(transport, otelhttp.WithTracerProvider(tracerProvider))
+	}
+
 	zones := c.Zones
 	if len(zones) == 0 {
 		zones = defaultZones
@@ -223,13 +322,26 @@ func (c *Config) NewClient() (*APIClient, error) {
 		vpcRouterWaitAfterCreateDuration = time.Millisecond
 	}
 
+	if c.MultiZone {
+		if err := validateZonesWithAPI(context.Background(), caller, append([]string{c.Zone}, zones...)); err != nil {
+			return nil, err
+		}
+	}
+
 	return &APIClient{
-		APICaller:                        caller,
-		defaultZone:                      c.Zone,
-		zones:                            zones,
+		APICaller:   caller,
+		defaultZone: c.Zone,
+		zones:       zones,
+		multiZone:   c.MultiZone,
+		defaultTags: types.Tags(c.DefaultTags),
+		ignoreTags: &ignoreTags{
+			keys:        c.IgnoreTagKeys,
+			keyPrefixes: c.IgnoreTagPrefixes,
+		},
 		deletionWaiterTimeout:            deletionWaiterTimeout,
 		deletionWaiterPollingInterval:    deletionWaiterPollingInterval,
 		databaseWaitAfterCreateDuration:  databaseWaitAfterCreateDuration,
 		vpcRouterWaitAfterCreateDuration: vpcRouterWaitAfterCreateDuration,
+		tracerProvider:                   tracerProvider,
 	}, nil
 }
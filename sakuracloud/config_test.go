@@ -279,3 +279,42 @@ func TestConfig_NewClient_loadFromProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestIgnoreTags_isIgnored(t *testing.T) {
+	ignore := &ignoreTags{
+		keys:        []string{"managed-by"},
+		keyPrefixes: []string{"@auto-"},
+	}
+
+	cases := map[string]bool{
+		"managed-by":    true,
+		"managed-by=x":  true,
+		"managed-byx":   false,
+		"@auto-reboot":  true,
+		"@auto":         false,
+		"env=prod":      false,
+		"x=managed-by":  false,
+		"x-@auto-start": false,
+	}
+	for tag, expect := range cases {
+		if got := ignore.isIgnored(tag); got != expect {
+			t.Errorf("isIgnored(%q): expected: %t actual: %t", tag, expect, got)
+		}
+	}
+
+	var empty *ignoreTags
+	if empty.isIgnored("managed-by") {
+		t.Error("nil ignoreTags must not ignore any tags")
+	}
+}
+
+func TestAPIClient_validateZone(t *testing.T) {
+	client := &APIClient{zones: []string{"is1a", "tk1a"}}
+
+	if err := client.validateZone("zone", "tk1a"); err != nil {
+		t.Errorf("APIClient.validateZone() returns unexpected error: %s", err)
+	}
+	if err := client.validateZone("zone", "tk1b"); err == nil {
+		t.Error("APIClient.validateZone() should return error with zone which is not in zones")
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+// 複数件を返すデータソース(sakuracloud_serversなど)で利用するソートキー
+const (
+	pluralSortByName      = "name"
+	pluralSortByCreatedAt = "created_at"
+
+	pluralSortOrderAsc  = "asc"
+	pluralSortOrderDesc = "desc"
+)
+
+var (
+	pluralSortByStrings    = []string{pluralSortByName, pluralSortByCreatedAt}
+	pluralSortOrderStrings = []string{pluralSortOrderAsc, pluralSortOrderDesc}
+)
+
+// pluralTarget 複数件を返すデータソースの検索結果
+type pluralTarget interface {
+	GetID() types.ID
+	GetName() string
+	GetCreatedAt() time.Time
+}
+
+// pluralDataSourceSchema 複数件を返すデータソースのスキーマを返す
+//
+// itemsAttrName で指定した名前で、共通の属性(id/name/description/tags/created_at)とitemSchemaを持つリストが定義される
+func pluralDataSourceSchema(resourceName, itemsAttrName string, filterOpt filterSchemaOption, itemSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	item := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: descf("The id of the %s", resourceName),
+		},
+		"name":        schemaDataSourceName(resourceName),
+		"description": schemaDataSourceDescription(resourceName),
+		"tags":        schemaDataSourceTags(resourceName),
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: descf("The date and time the %s was created", resourceName),
+		},
+		"zone": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: descf("The name of zone that the %s is in", resourceName),
+		},
+	}
+	for k, v := range itemSchema {
+		item[k] = v
+	}
+
+	return map[string]*schema.Schema{
+		filterAttrName: filterSchema(&filterSchemaOption{excludeTags: filterOpt.excludeTags, conditionNames: filterOpt.conditionNames, excludeSelector: true}),
+		"sort_by": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          pluralSortByName,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pluralSortByStrings, false)),
+			Description:      descf("The key to sort the results. This must be one of [%s]", pluralSortByStrings),
+		},
+		"sort_order": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          pluralSortOrderAsc,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pluralSortOrderStrings, false)),
+			Description:      descf("The order to sort the results. This must be one of [%s]", pluralSortOrderStrings),
+		},
+		"limit": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			Description:      "The maximum number of results. If this isn't specified, all results are returned",
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: descf("A list of the %s id", resourceName),
+		},
+		itemsAttrName: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Resource{Schema: item},
+			Description: descf("A list of the %s", resourceName),
+		},
+		"zone": schemaDataSourceZone(resourceName),
+	}
+}
+
+func expandPluralFindCondition(d resourceValueGettable) *sacloud.FindCondition {
+	findCondition := &sacloud.FindCondition{}
+	if rawFilter, ok := d.GetOk(filterAttrName); ok {
+		findCondition.Filter = expandSearchFilter(rawFilter)
+	}
+	return findCondition
+}
+
+// pluralFindFunc 指定ゾーンでconditionに一致するリソースを検索する
+type pluralFindFunc func(ctx context.Context, zone string, condition *sacloud.FindCondition) ([]pluralTarget, error)
+
+// pluralSearchZones 検索対象のゾーンを返す
+//
+// マルチゾーンモードでzoneが明示的に指定されていない場合はプロバイダーのzones全て、それ以外はzoneのみを返す
+func pluralSearchZones(d resourceValueGettable, client *APIClient, zone string) []string {
+	if client.multiZone && stringOrDefault(d, "zone") == "" {
+		return client.zones
+	}
+	return []string{zone}
+}
+
+// findPluralTargets 検索対象のゾーン全てでfindを呼び出し、selectPluralTargetsで絞り込んだ結果とリソースIDごとのゾーンを返す
+func findPluralTargets(ctx context.Context, d resourceValueGettable, client *APIClient, zone string, find pluralFindFunc) ([]pluralTarget, map[types.ID]string, error) {
+	condition := expandPluralFindCondition(d)
+	zones := make(map[types.ID]string)
+
+	var targets []pluralTarget
+	for _, z := range pluralSearchZones(d, client, zone) {
+		found, err := find(ctx, z, condition)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, t := range found {
+			zones[t.GetID()] = z
+		}
+		targets = append(targets, found...)
+	}
+
+	targets, err := selectPluralTargets(d, targets)
+	if err != nil {
+		return nil, nil, err
+	}
+	return targets, zones, nil
+}
+
+// selectPluralTargets クライアント側で評価するconditionで絞り込み、sort_by/sort_order/limitに従いソート/件数制限する
+func selectPluralTargets(d resourceValueGettable, targets []pluralTarget) ([]pluralTarget, error) {
+	var values []interface{}
+	for _, t := range targets {
+		values = append(values, t)
+	}
+	values, err := filterTargets(d, values)
+	if err != nil {
+		return nil, err
+	}
+	targets = nil
+	for _, v := range values {
+		targets = append(targets, v.(pluralTarget))
+	}
+
+	sortBy := stringOrDefault(d, "sort_by")
+	desc := stringOrDefault(d, "sort_order") == pluralSortOrderDesc
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if desc {
+			a, b = b, a
+		}
+		switch sortBy {
+		case pluralSortByCreatedAt:
+			if !a.GetCreatedAt().Equal(b.GetCreatedAt()) {
+				return a.GetCreatedAt().Before(b.GetCreatedAt())
+			}
+		default:
+			if a.GetName() != b.GetName() {
+				return a.GetName() < b.GetName()
+			}
+		}
+		return a.GetID() < b.GetID()
+	})
+
+	if limit := intOrDefault(d, "limit"); limit > 0 && len(targets) > limit {
+		targets = targets[:limit]
+	}
+	return targets, nil
+}
+
+func flattenPluralTarget(target pluralTarget, description string, tags types.Tags) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          target.GetID().String(),
+		"name":        target.GetName(),
+		"description": description,
+		"tags":        flattenTags(tags),
+		"created_at":  target.GetCreatedAt().Format(time.RFC3339),
+	}
+}
+
+func setPluralDataSourceResourceData(d *schema.ResourceData, client *APIClient, itemsAttrName string, targets []pluralTarget, zones map[types.ID]string, items []interface{}) diag.Diagnostics {
+	var ids []string
+	for i, t := range targets {
+		ids = append(ids, t.GetID().String())
+		items[i].(map[string]interface{})["zone"] = zones[t.GetID()]
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d", itemsAttrName, schema.HashString(strings.Join(ids, ","))))
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set(itemsAttrName, items); err != nil {
+		return diag.FromErr(err)
+	}
+	zone := getZone(d, client)
+	if len(pluralSearchZones(d, client, zone)) > 1 {
+		zone = "" // 複数ゾーンを検索した場合
+	}
+	d.Set("zone", zone) // nolint
+	return nil
+}
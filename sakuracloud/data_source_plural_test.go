@@ -0,0 +1,137 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourcePlural_selectPluralTargets(t *testing.T) {
+	now := time.Now()
+	servers := []*sacloud.Server{
+		{ID: 1, Name: "b", CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, Name: "c", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: 3, Name: "a", CreatedAt: now},
+		{ID: 4, Name: "a", CreatedAt: now.Add(-3 * time.Hour)},
+	}
+
+	cases := []struct {
+		msg    string
+		in     map[string]interface{}
+		expect []int64
+	}{
+		{
+			msg:    "sort by name",
+			in:     map[string]interface{}{"sort_by": "name", "sort_order": "asc"},
+			expect: []int64{3, 4, 1, 2},
+		},
+		{
+			msg:    "sort by name desc",
+			in:     map[string]interface{}{"sort_by": "name", "sort_order": "desc"},
+			expect: []int64{2, 1, 4, 3},
+		},
+		{
+			msg:    "sort by created_at",
+			in:     map[string]interface{}{"sort_by": "created_at", "sort_order": "asc"},
+			expect: []int64{4, 2, 1, 3},
+		},
+		{
+			msg:    "sort by created_at desc with limit",
+			in:     map[string]interface{}{"sort_by": "created_at", "sort_order": "desc", "limit": 2},
+			expect: []int64{3, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		var targets []pluralTarget
+		for _, s := range servers {
+			targets = append(targets, s)
+		}
+		selected, err := selectPluralTargets(mapToResourceData(tc.in), targets)
+		if !assert.NoError(t, err, tc.msg) {
+			continue
+		}
+		var ids []int64
+		for _, t := range selected {
+			ids = append(ids, t.GetID().Int64())
+		}
+		assert.Equal(t, tc.expect, ids, tc.msg)
+	}
+}
+
+func TestDataSourcePlural_findPluralTargets(t *testing.T) {
+	servers := map[string][]*sacloud.Server{
+		"is1a": {{ID: 1, Name: "b"}, {ID: 2, Name: "d"}},
+		"tk1a": {{ID: 3, Name: "a"}},
+		"tk1b": {{ID: 4, Name: "c"}},
+	}
+	find := func(ctx context.Context, zone string, condition *sacloud.FindCondition) ([]pluralTarget, error) {
+		var targets []pluralTarget
+		for _, s := range servers[zone] {
+			targets = append(targets, s)
+		}
+		return targets, nil
+	}
+
+	cases := []struct {
+		msg       string
+		multiZone bool
+		in        map[string]interface{}
+		expect    []int64
+		zones     map[types.ID]string
+	}{
+		{
+			msg:    "single zone",
+			in:     map[string]interface{}{"sort_by": "name"},
+			expect: []int64{1, 2},
+			zones:  map[types.ID]string{1: "is1a", 2: "is1a"},
+		},
+		{
+			msg:       "multi zone",
+			multiZone: true,
+			in:        map[string]interface{}{"sort_by": "name"},
+			expect:    []int64{3, 1, 2},
+			zones:     map[types.ID]string{1: "is1a", 2: "is1a", 3: "tk1a"},
+		},
+		{
+			msg:       "multi zone with zone",
+			multiZone: true,
+			in:        map[string]interface{}{"sort_by": "name", "zone": "tk1a"},
+			expect:    []int64{3},
+			zones:     map[types.ID]string{3: "tk1a"},
+		},
+	}
+
+	for _, tc := range cases {
+		client := &APIClient{defaultZone: "is1a", zones: []string{"is1a", "tk1a"}, multiZone: tc.multiZone}
+		d := mapToResourceData(tc.in)
+		targets, zones, err := findPluralTargets(context.Background(), d, client, getZone(d, client), find)
+		if !assert.NoError(t, err, tc.msg) {
+			continue
+		}
+		var ids []int64
+		for _, t := range targets {
+			ids = append(ids, t.GetID().Int64())
+		}
+		assert.Equal(t, tc.expect, ids, tc.msg)
+		assert.Equal(t, tc.zones, zones, tc.msg)
+	}
+}
@@ -32,7 +32,7 @@ func dataSourceSakuraCloudArchive() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudArchiveRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Scope", "Availability", "SizeMB"}}),
 			"os_type": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -85,11 +85,11 @@ func dataSourceSakuraCloudArchiveRead(ctx context.Context, d *schema.ResourceDat
 			return filterNoResultErr()
 		}
 
-		targets := res.Archives
-		if len(targets) == 0 {
-			return filterNoResultErr()
+		target, diags := selectFilteredTarget(d, res.Archives)
+		if target == nil {
+			return diags
 		}
-		data = targets[0]
+		data = target.(*sacloud.Archive)
 	}
 
 	if data != nil {
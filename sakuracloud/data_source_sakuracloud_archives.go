@@ -0,0 +1,66 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func dataSourceSakuraCloudArchives() *schema.Resource {
+	resourceName := "Archive"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudArchivesRead,
+
+		Schema: pluralDataSourceSchema(resourceName, "archives", filterSchemaOption{conditionNames: []string{"Scope", "Availability", "SizeMB"}}, map[string]*schema.Schema{
+			"size": schemaDataSourceSize(resourceName),
+		}),
+	}
+}
+
+func dataSourceSakuraCloudArchivesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	targets, zones, err := findPluralTargets(ctx, d, client, zone, func(ctx context.Context, zone string, condition *sacloud.FindCondition) ([]pluralTarget, error) {
+		res, err := sacloud.NewArchiveOp(client).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		var targets []pluralTarget
+		for _, v := range res.Archives {
+			targets = append(targets, v)
+		}
+		return targets, nil
+	})
+	if err != nil {
+		return diag.Errorf("could not find SakuraCloud Archive resources: %s", err)
+	}
+
+	var items []interface{}
+	for _, t := range targets {
+		data := t.(*sacloud.Archive)
+		item := flattenPluralTarget(data, data.Description, data.Tags)
+		item["size"] = data.GetSizeGB()
+		items = append(items, item)
+	}
+	return setPluralDataSourceResourceData(d, client, "archives", targets, zones, items)
+}
@@ -32,7 +32,31 @@ func dataSourceSakuraCloudBridge() *schema.Resource {
 			filterAttrName: filterSchema(&filterSchemaOption{excludeTags: true}),
 			"name":         schemaDataSourceName(resourceName),
 			"description":  schemaDataSourceDescription(resourceName),
-			"zone":         schemaDataSourceZone(resourceName),
+			"switches": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the Switch connected to the Bridge",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the Switch connected to the Bridge",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of zone that the Switch is in",
+						},
+					},
+				},
+				Description: "A list of the Switches connected to the Bridge across zones",
+			},
+			"zone": schemaDataSourceZone(resourceName),
 		},
 	}
 }
@@ -61,11 +85,26 @@ func dataSourceSakuraCloudBridgeRead(ctx context.Context, d *schema.ResourceData
 		return filterNoResultErr()
 	}
 
-	targets := res.Bridges
-	if len(targets) == 0 {
-		return filterNoResultErr()
+	target, diags := selectFilteredTarget(d, res.Bridges)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.Bridge)
+	d.SetId(data.ID.String())
+	if err := d.Set("switches", flattenBridgeSwitches(data)); err != nil {
+		return diag.FromErr(err)
 	}
+	return setBridgeResourceData(ctx, d, client, data)
+}
 
-	d.SetId(targets[0].ID.String())
-	return setBridgeResourceData(ctx, d, client, targets[0])
+func flattenBridgeSwitches(data *sacloud.Bridge) []interface{} {
+	var results []interface{}
+	for _, info := range data.BridgeInfo {
+		results = append(results, map[string]interface{}{
+			"id":   info.ID.String(),
+			"name": info.Name,
+			"zone": info.ZoneName,
+		})
+	}
+	return results
 }
@@ -34,6 +34,15 @@ func TestAccSakuraCloudDataSourceBridge_basic(t *testing.T) {
 					testCheckSakuraCloudDataSourceExists(resourceName),
 					resource.TestCheckResourceAttr(resourceName, "name", rand),
 					resource.TestCheckResourceAttr(resourceName, "description", "description"),
+					resource.TestCheckResourceAttr(resourceName, "switches.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "switches.0.id",
+						"sakuracloud_switch.foobar", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "switches.0.zone",
+						"sakuracloud_switch.foobar", "zone",
+					),
 				),
 			},
 		},
@@ -45,8 +54,16 @@ resource "sakuracloud_bridge" "foobar" {
   name        = "{{ .arg0 }}"
   description = "description"
 }
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+resource "sakuracloud_switch_bridge_connection" "foobar" {
+  switch_id = sakuracloud_switch.foobar.id
+  bridge_id = sakuracloud_bridge.foobar.id
+}
 data "sakuracloud_bridge" "foobar" {
   filter {
     names = [sakuracloud_bridge.foobar.name]
   }
+  depends_on = [sakuracloud_switch_bridge_connection.foobar]
 }`
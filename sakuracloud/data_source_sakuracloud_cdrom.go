@@ -28,7 +28,7 @@ func dataSourceSakuraCloudCDROM() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudCDROMRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Scope", "Availability", "SizeMB"}}),
 			"name":         schemaDataSourceName(resourceName),
 			"size":         schemaDataSourceSize(resourceName),
 			"icon_id":      schemaDataSourceIconID(resourceName),
@@ -60,8 +60,11 @@ func dataSourceSakuraCloudCDROMRead(ctx context.Context, d *schema.ResourceData,
 		return filterNoResultErr()
 	}
 
-	targets := res.CDROMs
-	target := targets[0]
+	selected, diags := selectFilteredTarget(d, res.CDROMs)
+	if selected == nil {
+		return diags
+	}
+	target := selected.(*sacloud.CDROM)
 
 	d.SetId(target.ID.String())
 	d.Set("name", target.Name)               // nolint
@@ -106,11 +106,11 @@ func dataSourceSakuraCloudContainerRegistryRead(ctx context.Context, d *schema.R
 		return filterNoResultErr()
 	}
 
-	targets := res.ContainerRegistries
-	if len(targets) == 0 {
-		return filterNoResultErr()
+	target, diags := selectFilteredTarget(d, res.ContainerRegistries)
+	if target == nil {
+		return diags
 	}
-
-	d.SetId(targets[0].ID.String())
-	return setContainerRegistryResourceData(ctx, d, client, targets[0], false)
+	data := target.(*sacloud.ContainerRegistry)
+	d.SetId(data.ID.String())
+	return setContainerRegistryResourceData(ctx, d, client, data, false)
 }
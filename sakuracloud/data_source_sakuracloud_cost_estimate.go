@@ -0,0 +1,287 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+// cost_estimateのapplianceで指定可能な種別
+const (
+	costEstimateApplianceInternet = "internet"
+	costEstimateApplianceProxyLB  = "proxylb"
+)
+
+var costEstimateApplianceTypes = []string{costEstimateApplianceInternet, costEstimateApplianceProxyLB}
+
+func dataSourceSakuraCloudCostEstimate() *schema.Resource {
+	resourceName := "ServiceClass"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudCostEstimateRead,
+
+		Schema: map[string]*schema.Schema{
+			"item": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_class_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The path of the service class to estimate. The value can be referenced from the `sakuracloud_service_class` data source. Exactly one of `service_class_path`, `server`, `disk` and `appliance` must be specified",
+						},
+						"server": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"core": {
+										Type:             schema.TypeInt,
+										Optional:         true,
+										Default:          1,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+										Description:      "The number of virtual CPUs",
+									},
+									"memory": {
+										Type:             schema.TypeInt,
+										Optional:         true,
+										Default:          1,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+										Description:      "The size of memory in GiB",
+									},
+									"commitment": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          types.Commitments.Standard.String(),
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.CommitmentStrings, false)),
+										Description: descf(
+											"The policy of how to allocate virtual CPUs to the server. This must be one of [%s]",
+											types.CommitmentStrings,
+										),
+									},
+								},
+							},
+							Description: "The spec of the server to estimate. The service class is resolved from the server plans",
+						},
+						"disk": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"plan": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          types.DiskPlanNameMap[types.DiskPlans.SSD],
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.DiskPlanStrings, false)),
+										Description:      descf("The plan name of the disk. This must be one of [%s]", types.DiskPlanStrings),
+									},
+									"size": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     20,
+										Description: "The size of the disk in GiB",
+									},
+								},
+							},
+							Description: "The spec of the disk to estimate. The service class is resolved from the disk plans",
+						},
+						"appliance": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(costEstimateApplianceTypes, false)),
+										Description:      descf("The type of the appliance. This must be one of [%s]", costEstimateApplianceTypes),
+									},
+									"plan": {
+										Type:        schema.TypeInt,
+										Required:    true,
+										Description: "The plan of the appliance. This is the bandwidth in Mbps for `internet`, and the CPS for `proxylb`",
+									},
+									"region": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Default:          types.ProxyLBRegions.IS1.String(),
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.ProxyLBRegionStrings, false)),
+										Description: descf(
+											"The name of region that the appliance is in. This is used only for `proxylb`. This must be one of [%s]",
+											types.ProxyLBRegionStrings,
+										),
+									},
+								},
+							},
+							Description: "The spec of the appliance to estimate",
+						},
+						"quantity": {
+							Type:             schema.TypeInt,
+							Optional:         true,
+							Default:          1,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+							Description:      "The number of the resources to estimate",
+						},
+					},
+				},
+				Description: "One or more service classes to estimate, as defined below",
+			},
+			"estimates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_class_path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The path of the service class",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The display name of the service class",
+						},
+						"quantity": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of the resources",
+						},
+						"unit_monthly_price": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The monthly price per resource in JPY",
+						},
+						"monthly_price": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The monthly price of the item in JPY",
+						},
+					},
+				},
+				Description: "A list of the estimated price per item",
+			},
+			"total_monthly_price": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The estimated monthly price of all items in JPY",
+			},
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudCostEstimateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var keys []string
+	var estimates []interface{}
+	total := 0
+	for _, raw := range d.Get("item").([]interface{}) {
+		v := mapToResourceData(raw.(map[string]interface{}))
+		path, err := expandCostEstimateServiceClassPath(ctx, client, zone, v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		quantity := v.Get("quantity").(int)
+
+		class, err := findServiceClass(ctx, client, zone, "ServiceClassPath", path)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		unitPrice := estimateMonthlyPrice(class.Price)
+		total += unitPrice * quantity
+		keys = append(keys, fmt.Sprintf("%s:%d", path, quantity))
+		estimates = append(estimates, map[string]interface{}{
+			"service_class_path": class.ServiceClassPath,
+			"display_name":       class.DisplayName,
+			"quantity":           quantity,
+			"unit_monthly_price": unitPrice,
+			"monthly_price":      unitPrice * quantity,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("cost-estimate-%s-%d", zone, schema.HashString(strings.Join(keys, ","))))
+	if err := d.Set("estimates", estimates); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("total_monthly_price", total) // nolint
+	d.Set("zone", getZone(d, client))   // nolint
+	return nil
+}
+
+// expandCostEstimateServiceClassPath itemのservice_class_path/server/disk/applianceのいずれかからサービスクラスのパスを返す
+//
+// server/disk/applianceが指定された場合はプランからサービスクラスを解決する
+func expandCostEstimateServiceClassPath(ctx context.Context, client *APIClient, zone string, d resourceValueGettable) (string, error) {
+	var specified []string
+	for _, key := range []string{"service_class_path", "server", "disk", "appliance"} {
+		if _, ok := d.GetOk(key); ok {
+			specified = append(specified, key)
+		}
+	}
+	if len(specified) != 1 {
+		return "", fmt.Errorf("exactly one of [service_class_path/server/disk/appliance] must be specified in item: got %q", specified)
+	}
+
+	switch specified[0] {
+	case "server":
+		v := mapToResourceData(d.Get("server").([]interface{})[0].(map[string]interface{}))
+		return findServerPlanServiceClass(ctx, sacloud.NewServerPlanOp(client), zone,
+			v.Get("core").(int), v.Get("memory").(int), types.ECommitment(v.Get("commitment").(string)))
+	case "disk":
+		v := mapToResourceData(d.Get("disk").([]interface{})[0].(map[string]interface{}))
+		return findDiskPlanServiceClass(ctx, sacloud.NewDiskPlanOp(client), zone, v.Get("plan").(string), v.Get("size").(int))
+	case "appliance":
+		v := mapToResourceData(d.Get("appliance").([]interface{})[0].(map[string]interface{}))
+		plan := v.Get("plan").(int)
+		switch v.Get("type").(string) {
+		case costEstimateApplianceInternet:
+			return findInternetPlanServiceClass(ctx, sacloud.NewInternetPlanOp(client), zone, plan)
+		case costEstimateApplianceProxyLB:
+			if !isProxyLBPlanValue(plan) {
+				return "", fmt.Errorf("proxylb plan[%d] is not found: this must be one of %v", plan, types.ProxyLBPlanValues)
+			}
+			return types.ProxyLBServiceClass(types.EProxyLBPlan(plan), types.EProxyLBRegion(v.Get("region").(string))), nil
+		}
+		return "", fmt.Errorf("unsupported appliance type: %s", v.Get("type"))
+	}
+	return d.Get("service_class_path").(string), nil
+}
+
+func isProxyLBPlanValue(plan int) bool {
+	for _, v := range types.ProxyLBPlanValues {
+		if v == plan {
+			return true
+		}
+	}
+	return false
+}
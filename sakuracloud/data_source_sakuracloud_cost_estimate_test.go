@@ -0,0 +1,172 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandCostEstimateServiceClassPath(t *testing.T) {
+	cases := []struct {
+		msg    string
+		in     map[string]interface{}
+		expect string
+		err    bool
+	}{
+		{
+			msg:    "service_class_path",
+			in:     map[string]interface{}{"service_class_path": "cloud/plan/1"},
+			expect: "cloud/plan/1",
+		},
+		{
+			msg: "proxylb",
+			in: map[string]interface{}{
+				"appliance": []interface{}{
+					map[string]interface{}{"type": "proxylb", "plan": 1000, "region": "is1"},
+				},
+			},
+			expect: "cloud/proxylb/plain/1000",
+		},
+		{
+			msg: "invalid proxylb plan",
+			in: map[string]interface{}{
+				"appliance": []interface{}{
+					map[string]interface{}{"type": "proxylb", "plan": 1, "region": "is1"},
+				},
+			},
+			err: true,
+		},
+		{
+			msg: "nothing is specified",
+			in:  map[string]interface{}{},
+			err: true,
+		},
+		{
+			msg: "multiple items are specified",
+			in: map[string]interface{}{
+				"service_class_path": "cloud/plan/1",
+				"disk": []interface{}{
+					map[string]interface{}{"plan": "ssd", "size": 20},
+				},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		path, err := expandCostEstimateServiceClassPath(context.Background(), &APIClient{}, "is1a", mapToResourceData(tc.in))
+		if tc.err {
+			assert.Error(t, err, tc.msg)
+			continue
+		}
+		assert.NoError(t, err, tc.msg)
+		assert.Equal(t, tc.expect, path, tc.msg)
+	}
+}
+
+func TestAccSakuraCloudDataSourceCostEstimate_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_cost_estimate.foobar"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSakuraCloudDataSourceCostEstimate_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "estimates.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "estimates.0.service_class_path", "cloud/plan/1"),
+					resource.TestCheckResourceAttr(resourceName, "estimates.0.quantity", "2"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "estimates.0.unit_monthly_price",
+						"data.sakuracloud_service_class.foobar", "monthly_price",
+					),
+					resource.TestCheckResourceAttrSet(resourceName, "total_monthly_price"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSakuraCloudDataSourceCostEstimate_spec(t *testing.T) {
+	skipIfFakeModeEnabled(t)
+
+	resourceName := "data.sakuracloud_cost_estimate.foobar"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSakuraCloudDataSourceCostEstimate_spec,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "estimates.#", "4"),
+					resource.TestCheckResourceAttrSet(resourceName, "estimates.0.service_class_path"),
+					resource.TestCheckResourceAttrSet(resourceName, "estimates.1.service_class_path"),
+					resource.TestCheckResourceAttrSet(resourceName, "estimates.2.service_class_path"),
+					resource.TestCheckResourceAttr(resourceName, "estimates.3.service_class_path", "cloud/proxylb/plain/100"),
+					resource.TestCheckResourceAttrSet(resourceName, "total_monthly_price"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceCostEstimate_basic = `
+data "sakuracloud_service_class" "foobar" {
+  service_class_path = "cloud/plan/1"
+}
+
+data "sakuracloud_cost_estimate" "foobar" {
+  item {
+    service_class_path = data.sakuracloud_service_class.foobar.service_class_path
+    quantity           = 2
+  }
+  item {
+    service_class_path = "cloud/plan/2"
+  }
+}`
+
+var testAccSakuraCloudDataSourceCostEstimate_spec = `
+data "sakuracloud_cost_estimate" "foobar" {
+  item {
+    server {
+      core   = 2
+      memory = 4
+    }
+  }
+  item {
+    disk {
+      plan = "ssd"
+      size = 40
+    }
+  }
+  item {
+    appliance {
+      type = "internet"
+      plan = 100
+    }
+  }
+  item {
+    appliance {
+      type = "proxylb"
+      plan = 100
+    }
+  }
+}`
@@ -137,7 +137,11 @@ func dataSourceSakuraCloudDatabaseRead(ctx context.Context, d *schema.ResourceDa
 		return filterNoResultErr()
 	}
 
-	targets := res.Databases
-	d.SetId(targets[0].ID.String())
-	return setDatabaseResourceData(ctx, d, client, targets[0], zone)
+	target, diags := selectFilteredTarget(d, res.Databases)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.Database)
+	d.SetId(data.ID.String())
+	return setDatabaseResourceData(ctx, d, client, data, zone)
 }
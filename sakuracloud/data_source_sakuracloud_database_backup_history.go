@@ -0,0 +1,92 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceSakuraCloudDatabaseBackupHistory() *schema.Resource {
+	resourceName := "Database Backup"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudDatabaseBackupHistoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"database_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Database",
+			},
+			"backups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: descf("The id of the %s", resourceName),
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: descf("The date and time the %s was created", resourceName),
+						},
+						"recovered_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: descf("The date and time the Database was last restored from the %s", resourceName),
+						},
+						"availability": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: descf("The availability of the %s", resourceName),
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: descf("The size of the %s in bytes", resourceName),
+						},
+					},
+				},
+				Description: descf("A list of the %s", resourceName),
+			},
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudDatabaseBackupHistoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databaseID := expandSakuraCloudID(d, "database_id")
+	histories, err := newDatabaseBackupOp(client).List(ctx, zone, databaseID)
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Database[%s] backup history: %s", databaseID, err)
+	}
+
+	d.SetId(databaseID.String())
+	d.Set("zone", getZone(d, client)) // nolint
+	return diag.FromErr(d.Set("backups", flattenDatabaseBackupHistories(histories)))
+}
@@ -0,0 +1,134 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func dataSourceSakuraCloudDatabaseMonitor() *schema.Resource {
+	resourceName := "Database"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudDatabaseMonitorRead,
+
+		Schema: map[string]*schema.Schema{
+			"database_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Database or the Database Read Replica",
+			},
+			"start": schemaDataSourceMonitorTime("start"),
+			"end":   schemaDataSourceMonitorTime("end"),
+			"cpu": schemaDataSourceMonitorValues("A list of the CPU time activity", map[string]string{
+				"cpu_time": "The CPU time",
+			}),
+			"disk": schemaDataSourceMonitorValues("A list of the disk activity", map[string]string{
+				"read":  "The amount of disk read",
+				"write": "The amount of disk write",
+			}),
+			"interface": schemaDataSourceMonitorValues("A list of the network interface activity", map[string]string{
+				"receive": "The amount of received traffic",
+				"send":    "The amount of sent traffic",
+			}),
+			"database": schemaDataSourceMonitorValues("A list of the database activity", map[string]string{
+				"total_memory_size":    "The total size of the memory",
+				"used_memory_size":     "The used size of the memory",
+				"total_disk1_size":     "The total size of the system disk",
+				"used_disk1_size":      "The used size of the system disk",
+				"total_disk2_size":     "The total size of the backup disk",
+				"used_disk2_size":      "The used size of the backup disk",
+				"binlog_used_size_kib": "The used size of the binary log in KiB",
+				"delay_time_sec":       "The replication delay in seconds",
+			}),
+			"latest_cpu_time":             schemaDataSourceMonitorLatestValue("The latest CPU time"),
+			"latest_disk_read":            schemaDataSourceMonitorLatestValue("The latest amount of disk read"),
+			"latest_disk_write":           schemaDataSourceMonitorLatestValue("The latest amount of disk write"),
+			"latest_interface_receive":    schemaDataSourceMonitorLatestValue("The latest amount of received traffic"),
+			"latest_interface_send":       schemaDataSourceMonitorLatestValue("The latest amount of sent traffic"),
+			"latest_memory_used_rate":     schemaDataSourceMonitorLatestValue("The latest usage rate of the memory in percent"),
+			"latest_disk1_used_rate":      schemaDataSourceMonitorLatestValue("The latest usage rate of the system disk in percent"),
+			"latest_disk2_used_rate":      schemaDataSourceMonitorLatestValue("The latest usage rate of the backup disk in percent"),
+			"latest_binlog_used_size_kib": schemaDataSourceMonitorLatestValue("The latest used size of the binary log in KiB"),
+			"latest_delay_time_sec":       schemaDataSourceMonitorLatestValue("The latest replication delay in seconds"),
+			"zone":                        schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudDatabaseMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dbOp := sacloud.NewDatabaseOp(client)
+	id := expandSakuraCloudID(d, "database_id")
+	condition := expandMonitorCondition(d)
+
+	cpu, err := dbOp.MonitorCPU(ctx, zone, id, condition)
+	if err != nil {
+		return diag.Errorf("could not read CPU activity of SakuraCloud Database[%s]: %s", id, err)
+	}
+	disk, err := dbOp.MonitorDisk(ctx, zone, id, condition)
+	if err != nil {
+		return diag.Errorf("could not read disk activity of SakuraCloud Database[%s]: %s", id, err)
+	}
+	nic, err := dbOp.MonitorInterface(ctx, zone, id, condition)
+	if err != nil {
+		return diag.Errorf("could not read interface activity of SakuraCloud Database[%s]: %s", id, err)
+	}
+	db, err := dbOp.MonitorDatabase(ctx, zone, id, condition)
+	if err != nil {
+		return diag.Errorf("could not read database activity of SakuraCloud Database[%s]: %s", id, err)
+	}
+
+	cpuValues, latestCPU := flattenMonitorCPUTimeValues(cpu)
+	diskValues, latestDisk := flattenMonitorDiskValues(disk)
+	nicValues, latestNIC := flattenMonitorInterfaceValues(nic)
+	dbValues, latestDB := flattenMonitorDatabaseValues(db)
+
+	d.SetId(id.String())
+	if err := d.Set("cpu", cpuValues); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("disk", diskValues); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("interface", nicValues); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", dbValues); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("latest_cpu_time", latestCPU.CPUTime)                                                      // nolint
+	d.Set("latest_disk_read", latestDisk.Read)                                                       // nolint
+	d.Set("latest_disk_write", latestDisk.Write)                                                     // nolint
+	d.Set("latest_interface_receive", latestNIC.Receive)                                             // nolint
+	d.Set("latest_interface_send", latestNIC.Send)                                                   // nolint
+	d.Set("latest_memory_used_rate", monitorRate(latestDB.UsedMemorySize, latestDB.TotalMemorySize)) // nolint
+	d.Set("latest_disk1_used_rate", monitorRate(latestDB.UsedDisk1Size, latestDB.TotalDisk1Size))    // nolint
+	d.Set("latest_disk2_used_rate", monitorRate(latestDB.UsedDisk2Size, latestDB.TotalDisk2Size))    // nolint
+	d.Set("latest_binlog_used_size_kib", latestDB.BinlogUsedSizeKiB)                                 // nolint
+	d.Set("latest_delay_time_sec", latestDB.DelayTimeSec)                                            // nolint
+	d.Set("zone", getZone(d, client))                                                                // nolint
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceDatabaseMonitor_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_database_monitor.foobar"
+	rand := randomName()
+	password := randomPassword()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceDatabaseMonitor_basic, rand, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "database_id",
+						"sakuracloud_database.foobar", "id",
+					),
+					resource.TestCheckResourceAttrSet(resourceName, "cpu.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "database.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "latest_delay_time_sec"),
+					resource.TestCheckResourceAttrSet(resourceName, "latest_disk2_used_rate"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceDatabaseMonitor_basic = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_database" "foobar" {
+  name     = "{{ .arg0 }}"
+  username = "defuser"
+  password = "{{ .arg1 }}"
+
+  network_interface {
+    switch_id  = sakuracloud_switch.foobar.id
+    ip_address = "192.168.154.101"
+    netmask    = 24
+    gateway    = "192.168.154.1"
+  }
+}
+
+data "sakuracloud_database_monitor" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+}`
@@ -0,0 +1,82 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func dataSourceSakuraCloudDatabases() *schema.Resource {
+	resourceName := "Database"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudDatabasesRead,
+
+		Schema: pluralDataSourceSchema(resourceName, "databases", filterSchemaOption{}, map[string]*schema.Schema{
+			"database_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: descf(
+					"The type of the database. This will be one of [%s]",
+					types.RDBMSTypeStrings,
+				),
+			},
+			"plan":       schemaDataSourcePlan(resourceName, types.DatabasePlanStrings),
+			"switch_id":  schemaDataSourceSwitchID(resourceName),
+			"ip_address": schemaDataSourceIPAddress(resourceName),
+		}),
+	}
+}
+
+func dataSourceSakuraCloudDatabasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	targets, zones, err := findPluralTargets(ctx, d, client, zone, func(ctx context.Context, zone string, condition *sacloud.FindCondition) ([]pluralTarget, error) {
+		res, err := sacloud.NewDatabaseOp(client).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		var targets []pluralTarget
+		for _, v := range res.Databases {
+			targets = append(targets, v)
+		}
+		return targets, nil
+	})
+	if err != nil {
+		return diag.Errorf("could not find SakuraCloud Database resources: %s", err)
+	}
+
+	var items []interface{}
+	for _, t := range targets {
+		data := t.(*sacloud.Database)
+		item := flattenPluralTarget(data, data.Description, data.Tags)
+		item["database_type"] = flattenDatabaseType(data)
+		item["plan"] = types.DatabasePlanNameMap[data.PlanID]
+		item["switch_id"] = data.SwitchID.String()
+		if len(data.IPAddresses) > 0 {
+			item["ip_address"] = data.IPAddresses[0]
+		}
+		items = append(items, item)
+	}
+	return setPluralDataSourceResourceData(d, client, "databases", targets, zones, items)
+}
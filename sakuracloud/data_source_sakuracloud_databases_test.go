@@ -0,0 +1,73 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceDatabases_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_databases.foobar"
+	rand := randomName()
+	password := randomPassword()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceDatabases_basic, rand, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "databases.0.name", rand),
+					resource.TestCheckResourceAttr(resourceName, "databases.0.database_type", "postgres"),
+					resource.TestCheckResourceAttr(resourceName, "databases.0.plan", "10g"),
+					resource.TestCheckResourceAttr(resourceName, "databases.0.ip_address", "192.168.156.101"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceDatabases_basic = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_database" "foobar" {
+  name     = "{{ .arg0 }}"
+  tags     = ["{{ .arg0 }}"]
+  username = "defuser"
+  password = "{{ .arg1 }}"
+
+  network_interface {
+    switch_id  = sakuracloud_switch.foobar.id
+    ip_address = "192.168.156.101"
+    netmask    = 24
+    gateway    = "192.168.156.1"
+  }
+}
+
+data "sakuracloud_databases" "foobar" {
+  filter {
+    tags = ["{{ .arg0 }}"]
+  }
+
+  depends_on = [sakuracloud_database.foobar]
+}
+`
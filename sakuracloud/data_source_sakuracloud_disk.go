@@ -30,7 +30,7 @@ func dataSourceSakuraCloudDisk() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudDiskRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Availability", "Connection", "SizeMB"}}),
 			"name":         schemaDataSourceName(resourceName),
 			"plan":         schemaDataSourcePlan(resourceName, types.DiskPlanStrings),
 			"connector": {
@@ -82,7 +82,11 @@ func dataSourceSakuraCloudDiskRead(ctx context.Context, d *schema.ResourceData,
 		return filterNoResultErr()
 	}
 
-	targets := res.Disks
-	d.SetId(targets[0].ID.String())
-	return setDiskResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.Disks)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.Disk)
+	d.SetId(data.ID.String())
+	return setDiskResourceData(ctx, d, client, data)
 }
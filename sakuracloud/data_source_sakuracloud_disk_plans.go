@@ -0,0 +1,125 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/pkg/size"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func dataSourceSakuraCloudDiskPlans() *schema.Resource {
+	resourceName := "Disk"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudDiskPlansRead,
+
+		Schema: map[string]*schema.Schema{
+			"plan": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.DiskPlanStrings, false)),
+				Description:      descf("The plan name of the disk used for filtering. This must be one of [%s]", types.DiskPlanStrings),
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The size of disk in GiB used for filtering. If this is specified, only plans that support the size are returned",
+			},
+			"available_only": schemaDataSourcePlanAvailableOnly(),
+			"ids":            schemaDataSourcePlanIDs(resourceName),
+			"plans": schemaDataSourcePlans(resourceName, map[string]*schema.Schema{
+				"plan": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: descf("The plan name of the disk. This will be one of [%s]", types.DiskPlanStrings),
+				},
+				"storage_class": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The storage class of the disk plan",
+				},
+				"sizes": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeInt},
+					Description: "A list of the disk size in GiB supported by the plan",
+				},
+			}),
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudDiskPlansRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	planOp := sacloud.NewDiskPlanOp(client)
+	res, err := planOp.Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return diag.Errorf("could not find SakuraCloud DiskPlan resources: %s", err)
+	}
+
+	var ids []string
+	var plans []interface{}
+	for _, plan := range res.DiskPlans {
+		if !isDiskPlanMatched(d, plan) {
+			continue
+		}
+		ids = append(ids, plan.ID.String())
+		plans = append(plans, map[string]interface{}{
+			"id":            plan.ID.String(),
+			"name":          plan.Name,
+			"availability":  string(plan.Availability),
+			"plan":          types.DiskPlanNameMap[plan.ID],
+			"storage_class": plan.StorageClass,
+			"sizes":         flattenDiskPlanSizes(d, plan),
+		})
+	}
+	return setPlanDataSourceResourceData(d, client, "disk-plans", ids, plans)
+}
+
+func isDiskPlanMatched(d resourceValueGettable, plan *sacloud.DiskPlan) bool {
+	if !isPlanAvailabilityMatched(d, plan.Availability) || !isPlanStringValueMatched(d, "plan", types.DiskPlanNameMap[plan.ID]) {
+		return false
+	}
+	if v, ok := d.GetOk("size"); ok {
+		for _, s := range flattenDiskPlanSizes(d, plan) {
+			if s == v.(int) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func flattenDiskPlanSizes(d resourceValueGettable, plan *sacloud.DiskPlan) []int {
+	var sizes []int
+	for _, s := range plan.Size {
+		if isPlanAvailabilityMatched(d, s.Availability) {
+			sizes = append(sizes, size.MiBToGiB(s.SizeMB))
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,59 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceDisks_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_disks.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceDisks_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "disks.0.name", rand+"-0"),
+					resource.TestCheckResourceAttr(resourceName, "disks.0.plan", "ssd"),
+					resource.TestCheckResourceAttr(resourceName, "disks.0.size", "20"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceDisks_basic = `
+resource "sakuracloud_disk" "foobar" {
+  count = 2
+  name  = "{{ .arg0 }}-${count.index}"
+  tags  = ["{{ .arg0 }}"]
+}
+
+data "sakuracloud_disks" "foobar" {
+  filter {
+    tags = ["{{ .arg0 }}"]
+  }
+
+  depends_on = [sakuracloud_disk.foobar]
+}
+`
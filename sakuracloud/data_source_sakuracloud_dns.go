@@ -108,7 +108,11 @@ func dataSourceSakuraCloudDNSRead(ctx context.Context, d *schema.ResourceData, m
 		return filterNoResultErr()
 	}
 
-	targets := res.DNS
-	d.SetId(targets[0].ID.String())
-	return setDNSResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.DNS)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.DNS)
+	d.SetId(data.ID.String())
+	return setDNSResourceData(ctx, d, client, data)
 }
@@ -68,11 +68,11 @@ func dataSourceSakuraCloudESMERead(ctx context.Context, d *schema.ResourceData,
 		return filterNoResultErr()
 	}
 
-	targets := res.ESME
-	if len(targets) == 0 {
-		return filterNoResultErr()
+	target, diags := selectFilteredTarget(d, res.ESME)
+	if target == nil {
+		return diags
 	}
-
-	d.SetId(targets[0].ID.String())
-	return setESMEResourceData(d, client, targets[0])
+	data := target.(*sacloud.ESME)
+	d.SetId(data.ID.String())
+	return setESMEResourceData(d, client, data)
 }
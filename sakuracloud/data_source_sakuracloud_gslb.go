@@ -138,7 +138,11 @@ func dataSourceSakuraCloudGSLBRead(ctx context.Context, d *schema.ResourceData,
 		return filterNoResultErr()
 	}
 
-	targets := res.GSLBs
-	d.SetId(targets[0].ID.String())
-	return setGSLBResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.GSLBs)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.GSLB)
+	d.SetId(data.ID.String())
+	return setGSLBResourceData(ctx, d, client, data)
 }
@@ -28,7 +28,7 @@ func dataSourceSakuraCloudIcon() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudIconRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Scope"}}),
 			"name":         schemaDataSourceName(resourceName),
 			"tags":         schemaDataSourceTags(resourceName),
 			"url": {
@@ -61,8 +61,11 @@ func dataSourceSakuraCloudIconRead(ctx context.Context, d *schema.ResourceData,
 		return filterNoResultErr()
 	}
 
-	targets := res.Icons
-	icon := targets[0]
+	target, diags := selectFilteredTarget(d, res.Icons)
+	if target == nil {
+		return diags
+	}
+	icon := target.(*sacloud.Icon)
 
 	d.SetId(icon.ID.String())
 	return setIconResourceData(ctx, d, client, icon)
@@ -114,7 +114,11 @@ func dataSourceSakuraCloudInternetRead(ctx context.Context, d *schema.ResourceDa
 		return filterNoResultErr()
 	}
 
-	targets := res.Internet
-	d.SetId(targets[0].ID.String())
-	return setInternetResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.Internet)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.Internet)
+	d.SetId(data.ID.String())
+	return setInternetResourceData(ctx, d, client, data)
 }
@@ -0,0 +1,157 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func dataSourceSakuraCloudInternetMonitor() *schema.Resource {
+	resourceName := "Switch+Router"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudInternetMonitorRead,
+
+		Schema: map[string]*schema.Schema{
+			"internet_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Switch+Router",
+			},
+			"start": schemaDataSourceMonitorTime("start"),
+			"end":   schemaDataSourceMonitorTime("end"),
+			"percentile": {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				Default:          95,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(1, 100)),
+				Description:      "The percentile used to calculate `in_percentile`, `out_percentile` and `recommended_band_width`",
+			},
+			"headroom": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          20,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 100)),
+				Description:      "The headroom in percent added to the percentile traffic when calculating `recommended_band_width`",
+			},
+			"router": schemaDataSourceMonitorValues("A list of the router traffic activity", map[string]string{
+				"in":  "The inbound traffic in bps",
+				"out": "The outbound traffic in bps",
+			}),
+			"in_percentile": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The inbound traffic in bps at the `percentile`",
+			},
+			"out_percentile": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The outbound traffic in bps at the `percentile`",
+			},
+			"latest_in":  schemaDataSourceMonitorLatestValue("The latest inbound traffic in bps"),
+			"latest_out": schemaDataSourceMonitorLatestValue("The latest outbound traffic in bps"),
+			"band_width": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The current bandwidth of the network connected to the Internet in Mbps",
+			},
+			"recommended_band_width": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The smallest available bandwidth in Mbps which can accommodate the larger of `in_percentile` and `out_percentile` with the `headroom`. This is the same as `band_width` when there is no monitored value",
+			},
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudInternetMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	internetOp := sacloud.NewInternetOp(client)
+	id := expandSakuraCloudID(d, "internet_id")
+	condition := expandMonitorCondition(d)
+
+	internet, err := readInternet(ctx, client, zone, id)
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Internet[%s]: %s", id, err)
+	}
+
+	activity, err := internetOp.MonitorRouter(ctx, zone, internet.ID, condition)
+	if err != nil {
+		return diag.Errorf("could not read router activity of SakuraCloud Internet[%s]: %s", id, err)
+	}
+	routerValues, latest := flattenMonitorRouterValues(activity)
+
+	var inValues, outValues []float64
+	for _, v := range activity.Values {
+		inValues = append(inValues, v.In)
+		outValues = append(outValues, v.Out)
+	}
+	percentile := d.Get("percentile").(float64)
+	inPercentile := monitorPercentile(inValues, percentile)
+	outPercentile := monitorPercentile(outValues, percentile)
+
+	recommended := internet.BandWidthMbps
+	if len(activity.Values) > 0 {
+		bandWidths, err := expandAvailableInternetBandWidths(ctx, client, zone)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		peak := inPercentile
+		if outPercentile > peak {
+			peak = outPercentile
+		}
+		recommended = recommendInternetBandWidth(peak, d.Get("headroom").(int), bandWidths)
+	}
+
+	d.SetId(id.String())
+	if err := d.Set("router", routerValues); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("in_percentile", inPercentile)         // nolint
+	d.Set("out_percentile", outPercentile)       // nolint
+	d.Set("latest_in", latest.In)                // nolint
+	d.Set("latest_out", latest.Out)              // nolint
+	d.Set("band_width", internet.BandWidthMbps)  // nolint
+	d.Set("recommended_band_width", recommended) // nolint
+	d.Set("zone", getZone(d, client))            // nolint
+	return nil
+}
+
+// expandAvailableInternetBandWidths ゾーン内で利用可能なスイッチ+ルータの帯域幅(Mbps)のリストを返す
+func expandAvailableInternetBandWidths(ctx context.Context, client *APIClient, zone string) ([]int, error) {
+	res, err := sacloud.NewInternetPlanOp(client).Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return nil, fmt.Errorf("could not find SakuraCloud InternetPlan resources: %s", err)
+	}
+	var bandWidths []int
+	for _, plan := range res.InternetPlans {
+		if plan.Availability.IsAvailable() {
+			bandWidths = append(bandWidths, plan.BandWidthMbps)
+		}
+	}
+	return bandWidths, nil
+}
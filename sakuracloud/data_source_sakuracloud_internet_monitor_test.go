@@ -0,0 +1,58 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceInternetMonitor_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_internet_monitor.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceInternetMonitor_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "internet_id",
+						"sakuracloud_internet.foobar", "id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "band_width", "100"),
+					resource.TestCheckResourceAttrSet(resourceName, "router.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "in_percentile"),
+					resource.TestCheckResourceAttrSet(resourceName, "out_percentile"),
+					resource.TestCheckResourceAttrSet(resourceName, "recommended_band_width"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceInternetMonitor_basic = `
+resource "sakuracloud_internet" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+data "sakuracloud_internet_monitor" "foobar" {
+  internet_id = sakuracloud_internet.foobar.id
+  percentile  = 95
+}`
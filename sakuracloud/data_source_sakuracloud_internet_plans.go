@@ -0,0 +1,84 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func dataSourceSakuraCloudInternetPlans() *schema.Resource {
+	resourceName := "Internet"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudInternetPlansRead,
+
+		Schema: map[string]*schema.Schema{
+			"band_width": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntInSlice(types.InternetBandWidths)),
+				Description: descf(
+					"The bandwidth of the network connected to the Internet in Mbps used for filtering. This must be one of [%s]",
+					types.InternetBandWidths,
+				),
+			},
+			"available_only": schemaDataSourcePlanAvailableOnly(),
+			"ids":            schemaDataSourcePlanIDs(resourceName),
+			"plans": schemaDataSourcePlans(resourceName, map[string]*schema.Schema{
+				"band_width": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The bandwidth of the network connected to the Internet in Mbps",
+				},
+			}),
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudInternetPlansRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	planOp := sacloud.NewInternetPlanOp(client)
+	res, err := planOp.Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return diag.Errorf("could not find SakuraCloud InternetPlan resources: %s", err)
+	}
+
+	var ids []string
+	var plans []interface{}
+	for _, plan := range res.InternetPlans {
+		if !isPlanAvailabilityMatched(d, plan.Availability) || !isPlanIntValueMatched(d, "band_width", plan.BandWidthMbps) {
+			continue
+		}
+		ids = append(ids, plan.ID.String())
+		plans = append(plans, map[string]interface{}{
+			"id":           plan.ID.String(),
+			"name":         plan.Name,
+			"availability": string(plan.Availability),
+			"band_width":   plan.BandWidthMbps,
+		})
+	}
+	return setPlanDataSourceResourceData(d, client, "internet-plans", ids, plans)
+}
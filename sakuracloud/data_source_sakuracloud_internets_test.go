@@ -0,0 +1,58 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceInternets_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_internets.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceInternets_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "internets.0.name", rand),
+					resource.TestCheckResourceAttr(resourceName, "internets.0.netmask", "28"),
+					resource.TestCheckResourceAttr(resourceName, "internets.0.band_width", "100"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceInternets_basic = `
+resource "sakuracloud_internet" "foobar" {
+  name = "{{ .arg0 }}"
+  tags = ["{{ .arg0 }}"]
+}
+
+data "sakuracloud_internets" "foobar" {
+  filter {
+    tags = ["{{ .arg0 }}"]
+  }
+
+  depends_on = [sakuracloud_internet.foobar]
+}
+`
@@ -143,7 +143,11 @@ func dataSourceSakuraCloudLoadBalancerRead(ctx context.Context, d *schema.Resour
 		return filterNoResultErr()
 	}
 
-	targets := res.LoadBalancers
-	d.SetId(targets[0].ID.String())
-	return setLoadBalancerResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.LoadBalancers)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.LoadBalancer)
+	d.SetId(data.ID.String())
+	return setLoadBalancerResourceData(ctx, d, client, data)
 }
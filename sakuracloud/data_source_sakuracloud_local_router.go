@@ -153,7 +153,11 @@ func dataSourceSakuraCloudLocalRouterRead(ctx context.Context, d *schema.Resourc
 		return filterNoResultErr()
 	}
 
-	targets := res.LocalRouters
-	d.SetId(targets[0].ID.String())
-	return setLocalRouterResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.LocalRouters)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.LocalRouter)
+	d.SetId(data.ID.String())
+	return setLocalRouterResourceData(ctx, d, client, data)
 }
@@ -0,0 +1,120 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func dataSourceSakuraCloudLocalRouterMonitor() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudLocalRouterMonitorRead,
+
+		Schema: map[string]*schema.Schema{
+			"local_router_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the LocalRouter",
+			},
+			"start": schemaDataSourceMonitorTime("start"),
+			"end":   schemaDataSourceMonitorTime("end"),
+			"local_router": schemaDataSourceMonitorValues("A list of the LocalRouter traffic activity", map[string]string{
+				"receive_bytes_per_sec": "The amount of received traffic in bytes per second",
+				"send_bytes_per_sec":    "The amount of sent traffic in bytes per second",
+			}),
+			"latest_receive_bytes_per_sec": schemaDataSourceMonitorLatestValue("The latest amount of received traffic in bytes per second"),
+			"latest_send_bytes_per_sec":    schemaDataSourceMonitorLatestValue("The latest amount of sent traffic in bytes per second"),
+			"peer": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"peer_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the peer LocalRouter",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The health status of the peer",
+						},
+						"routes": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "A list of the CIDR blocks advertised from the peer",
+						},
+					},
+				},
+				Description: "A list of the health status of the peers",
+			},
+		},
+	}
+}
+
+func dataSourceSakuraCloudLocalRouterMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lrOp := sacloud.NewLocalRouterOp(client)
+	id := expandSakuraCloudID(d, "local_router_id")
+	condition := expandMonitorCondition(d)
+
+	activity, err := lrOp.MonitorLocalRouter(ctx, id, condition)
+	if err != nil {
+		return diag.Errorf("could not read activity of SakuraCloud LocalRouter[%s]: %s", id, err)
+	}
+	health, err := lrOp.HealthStatus(ctx, id)
+	if err != nil && !sacloud.IsNotFoundError(err) {
+		return diag.Errorf("could not read health status of SakuraCloud LocalRouter[%s]: %s", id, err)
+	}
+
+	values, latest := flattenMonitorLocalRouterValues(activity)
+
+	d.SetId(id.String())
+	if err := d.Set("local_router", values); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("peer", flattenLocalRouterHealthPeers(health)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("latest_receive_bytes_per_sec", latest.ReceiveBytesPerSec) // nolint
+	d.Set("latest_send_bytes_per_sec", latest.SendBytesPerSec)       // nolint
+	return nil
+}
+
+func flattenLocalRouterHealthPeers(health *sacloud.LocalRouterHealth) []interface{} {
+	var results []interface{}
+	if health == nil {
+		return results
+	}
+	for _, peer := range health.Peers {
+		results = append(results, map[string]interface{}{
+			"peer_id": peer.ID.String(),
+			"status":  string(peer.Status),
+			"routes":  peer.Routes,
+		})
+	}
+	return results
+}
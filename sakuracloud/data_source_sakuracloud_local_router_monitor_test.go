@@ -0,0 +1,62 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceLocalRouterMonitor_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_local_router_monitor.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceLocalRouterMonitor_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "local_router_id",
+						"sakuracloud_local_router.foobar1", "id",
+					),
+					resource.TestCheckResourceAttrSet(resourceName, "local_router.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "latest_receive_bytes_per_sec"),
+					resource.TestCheckResourceAttrSet(resourceName, "latest_send_bytes_per_sec"),
+					resource.TestCheckResourceAttr(resourceName, "peer.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "peer.0.peer_id",
+						"sakuracloud_local_router.foobar2", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceLocalRouterMonitor_basic = testAccSakuraCloudLocalRouterPeer_routers + `
+resource "sakuracloud_local_router_peer" "foobar" {
+  local_router_id = sakuracloud_local_router.foobar1.id
+  peer_id         = sakuracloud_local_router.foobar2.id
+}
+
+data "sakuracloud_local_router_monitor" "foobar" {
+  local_router_id = sakuracloud_local_router_peer.foobar.local_router_id
+}
+`
@@ -74,7 +74,11 @@ func dataSourceSakuraCloudNFSRead(ctx context.Context, d *schema.ResourceData, m
 		return filterNoResultErr()
 	}
 
-	targets := res.NFS
-	d.SetId(targets[0].ID.String())
-	return setNFSResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.NFS)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.NFS)
+	d.SetId(data.ID.String())
+	return setNFSResourceData(ctx, d, client, data)
 }
@@ -0,0 +1,107 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func dataSourceSakuraCloudNFSMonitor() *schema.Resource {
+	resourceName := "NFS"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudNFSMonitorRead,
+
+		Schema: map[string]*schema.Schema{
+			"nfs_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the NFS",
+			},
+			"start": schemaDataSourceMonitorTime("start"),
+			"end":   schemaDataSourceMonitorTime("end"),
+			"free_disk_size": schemaDataSourceMonitorValues("A list of the free disk size activity", map[string]string{
+				"free_disk_size": "The free disk size in KiB",
+			}),
+			"interface": schemaDataSourceMonitorValues("A list of the network interface activity", map[string]string{
+				"receive": "The amount of received traffic",
+				"send":    "The amount of sent traffic",
+			}),
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the NFS in GiB",
+			},
+			"latest_free_disk_size":    schemaDataSourceMonitorLatestValue("The latest free disk size in KiB"),
+			"latest_free_disk_rate":    schemaDataSourceMonitorLatestValue("The latest rate of the free disk size to the size of the NFS in percent"),
+			"latest_interface_receive": schemaDataSourceMonitorLatestValue("The latest amount of received traffic"),
+			"latest_interface_send":    schemaDataSourceMonitorLatestValue("The latest amount of sent traffic"),
+			"zone":                     schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudNFSMonitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nfsOp := sacloud.NewNFSOp(client)
+	id := expandSakuraCloudID(d, "nfs_id")
+	condition := expandMonitorCondition(d)
+
+	nfs, err := nfsOp.Read(ctx, zone, id)
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud NFS[%s]: %s", id, err)
+	}
+	_, size, err := flattenNFSDiskPlan(ctx, client, nfs.PlanID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	freeDiskSize, err := nfsOp.MonitorFreeDiskSize(ctx, zone, id, condition)
+	if err != nil {
+		return diag.Errorf("could not read free disk size activity of SakuraCloud NFS[%s]: %s", id, err)
+	}
+	nic, err := nfsOp.MonitorInterface(ctx, zone, id, condition)
+	if err != nil {
+		return diag.Errorf("could not read interface activity of SakuraCloud NFS[%s]: %s", id, err)
+	}
+
+	freeDiskSizeValues, latestFreeDiskSize := flattenMonitorFreeDiskSizeValues(freeDiskSize)
+	nicValues, latestNIC := flattenMonitorInterfaceValues(nic)
+
+	d.SetId(id.String())
+	if err := d.Set("free_disk_size", freeDiskSizeValues); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("interface", nicValues); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("size", size)                                                                                   // nolint
+	d.Set("latest_free_disk_size", latestFreeDiskSize.FreeDiskSize)                                       // nolint
+	d.Set("latest_free_disk_rate", monitorRate(latestFreeDiskSize.FreeDiskSize, float64(size)*1024*1024)) // nolint
+	d.Set("latest_interface_receive", latestNIC.Receive)                                                  // nolint
+	d.Set("latest_interface_send", latestNIC.Send)                                                        // nolint
+	d.Set("zone", getZone(d, client))                                                                     // nolint
+	return nil
+}
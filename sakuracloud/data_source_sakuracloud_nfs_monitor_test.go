@@ -0,0 +1,67 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceNFSMonitor_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_nfs_monitor.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceNFSMonitor_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "nfs_id",
+						"sakuracloud_nfs.foobar", "id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "size", "100"),
+					resource.TestCheckResourceAttrSet(resourceName, "free_disk_size.#"),
+					resource.TestCheckResourceAttrSet(resourceName, "latest_free_disk_size"),
+					resource.TestCheckResourceAttrSet(resourceName, "latest_free_disk_rate"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceNFSMonitor_basic = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_nfs" "foobar" {
+  name = "{{ .arg0 }}"
+
+  network_interface {
+    switch_id  = sakuracloud_switch.foobar.id
+    ip_address = "192.168.155.101"
+    netmask    = 24
+    gateway    = "192.168.155.1"
+  }
+}
+
+data "sakuracloud_nfs_monitor" "foobar" {
+  nfs_id = sakuracloud_nfs.foobar.id
+}`
@@ -30,7 +30,7 @@ func dataSourceSakuraCloudNote() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudNoteRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Scope", "Class"}}),
 			"name":         schemaDataSourceName(resourceName),
 			"content": {
 				Type:        schema.TypeString,
@@ -66,7 +66,11 @@ func dataSourceSakuraCloudNoteRead(ctx context.Context, d *schema.ResourceData,
 		return filterNoResultErr()
 	}
 
-	targets := res.Notes
-	d.SetId(targets[0].ID.String())
-	return setNoteResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.Notes)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.Note)
+	d.SetId(data.ID.String())
+	return setNoteResourceData(ctx, d, client, data)
 }
@@ -69,6 +69,12 @@ func dataSourceSakuraCloudPacketFilter() *schema.Resource {
 					},
 				},
 			},
+			"expression_warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of warnings found by analyzing the expressions, such as shadowed, duplicate or unreachable rules",
+			},
 			"zone": schemaDataSourceZone(resourceName),
 		},
 	}
@@ -95,7 +101,11 @@ func dataSourceSakuraCloudPacketFilterRead(ctx context.Context, d *schema.Resour
 		return filterNoResultErr()
 	}
 
-	targets := res.PacketFilters
-	d.SetId(targets[0].ID.String())
-	return setPacketFilterResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.PacketFilters)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.PacketFilter)
+	d.SetId(data.ID.String())
+	return setPacketFilterResourceData(ctx, d, client, data)
 }
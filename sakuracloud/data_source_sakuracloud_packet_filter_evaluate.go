@@ -0,0 +1,142 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func dataSourceSakuraCloudPacketFilterEvaluate() *schema.Resource {
+	resourceName := "PacketFilter"
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudPacketFilterEvaluateRead,
+
+		Schema: map[string]*schema.Schema{
+			"packet_filter_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      descf("The id of the %s to evaluate", resourceName),
+			},
+			"protocol": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.PacketFilterProtocolStrings, false)),
+				Description: descf(
+					"The protocol of the packet. This must be one of [%s]",
+					types.PacketFilterProtocolStrings,
+				),
+			},
+			"source_address": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPv4Address),
+				Description:      "The source IP address of the packet",
+			},
+			"source_port": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 65535)),
+				Description:      "The source port number of the packet. This is used only when `protocol` is `tcp` or `udp`",
+			},
+			"destination_port": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 65535)),
+				Description:      "The destination port number of the packet. This is used only when `protocol` is `tcp` or `udp`",
+			},
+			"matched": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The flag that indicates whether the packet matches any expression",
+			},
+			"expression_index": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The index of the expression that the packet matches first. This will be `-1` if the packet matches no expression",
+			},
+			"allow": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The flag that indicates whether the packet is allowed. The packet that matches no expression is allowed",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the expression that the packet matches first",
+			},
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudPacketFilterEvaluateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pfID := d.Get("packet_filter_id").(string)
+	pf, err := sacloud.NewPacketFilterOp(client).Read(ctx, zone, sakuraCloudID(pfID))
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud PacketFilter[%s]: %s", pfID, err)
+	}
+
+	packet, err := expandPacketFilterPacket(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	index, err := evaluatePacketFilterExpressions(pf.Expression, packet)
+	if err != nil {
+		return diag.Errorf("evaluating SakuraCloud PacketFilter[%s] is failed: %s", pfID, err)
+	}
+
+	d.SetId(pf.ID.String())
+	return setPacketFilterEvaluateResourceData(ctx, d, client, pf, index)
+}
+
+func expandPacketFilterPacket(d resourceValueGettable) (*packetFilterPacket, error) {
+	source, err := parsePacketFilterIPv4(d.Get("source_address").(string))
+	if err != nil {
+		return nil, err
+	}
+	return &packetFilterPacket{
+		protocol:        types.Protocol(d.Get("protocol").(string)),
+		source:          source,
+		sourcePort:      d.Get("source_port").(int),
+		destinationPort: d.Get("destination_port").(int),
+	}, nil
+}
+
+func setPacketFilterEvaluateResourceData(_ context.Context, d *schema.ResourceData, client *APIClient, pf *sacloud.PacketFilter, index int) diag.Diagnostics {
+	allow := true
+	description := ""
+	if index >= 0 {
+		allow = pf.Expression[index].Action.IsAllow()
+		description = pf.Expression[index].Description
+	}
+	d.Set("matched", index >= 0)      // nolint
+	d.Set("expression_index", index)  // nolint
+	d.Set("allow", allow)             // nolint
+	d.Set("description", description) // nolint
+	d.Set("zone", getZone(d, client)) // nolint
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourcePacketFilterEvaluate_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_packet_filter_evaluate.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourcePacketFilterEvaluate_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "matched", "true"),
+					resource.TestCheckResourceAttr(resourceName, "expression_index", "1"),
+					resource.TestCheckResourceAttr(resourceName, "allow", "false"),
+					resource.TestCheckResourceAttr(resourceName, "description", "deny-all"),
+					resource.TestCheckResourceAttr("sakuracloud_packet_filter.foobar", "expression_warnings.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourcePacketFilterEvaluate_basic = `
+resource "sakuracloud_packet_filter" "foobar" {
+  name = "{{ .arg0 }}"
+  expression {
+    protocol         = "tcp"
+    source_network   = "192.0.2.0/24"
+    destination_port = "22"
+    allow            = true
+  }
+  expression {
+    protocol    = "ip"
+    allow       = false
+    description = "deny-all"
+  }
+}
+
+data "sakuracloud_packet_filter_evaluate" "foobar" {
+  packet_filter_id = sakuracloud_packet_filter.foobar.id
+  protocol         = "tcp"
+  source_address   = "198.51.100.1"
+  source_port      = 40000
+  destination_port = 22
+}
+`
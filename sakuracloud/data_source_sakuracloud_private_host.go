@@ -76,7 +76,11 @@ func dataSourceSakuraCloudPrivateHostRead(ctx context.Context, d *schema.Resourc
 		return filterNoResultErr()
 	}
 
-	targets := res.PrivateHosts
-	d.SetId(targets[0].ID.String())
-	return setPrivateHostResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.PrivateHosts)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.PrivateHost)
+	d.SetId(data.ID.String())
+	return setPrivateHostResourceData(ctx, d, client, data)
 }
@@ -0,0 +1,110 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func dataSourceSakuraCloudPrivateHostPlans() *schema.Resource {
+	resourceName := "PrivateHost"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudPrivateHostPlansRead,
+
+		Schema: map[string]*schema.Schema{
+			"class": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.PrivateHostClasses, false)),
+				Description:      descf("The class of the private host used for filtering. This must be one of [%s]", types.PrivateHostClasses),
+			},
+			"core": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of virtual CPUs used for filtering",
+			},
+			"memory": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The size of memory in GiB used for filtering",
+			},
+			"available_only": schemaDataSourcePlanAvailableOnly(),
+			"ids":            schemaDataSourcePlanIDs(resourceName),
+			"plans": schemaDataSourcePlans(resourceName, map[string]*schema.Schema{
+				"class": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The class of the private host",
+				},
+				"core": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of virtual CPUs",
+				},
+				"memory": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The size of memory in GiB",
+				},
+			}),
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudPrivateHostPlansRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	planOp := sacloud.NewPrivateHostPlanOp(client)
+	res, err := planOp.Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return diag.Errorf("could not find SakuraCloud PrivateHostPlan resources: %s", err)
+	}
+
+	var ids []string
+	var plans []interface{}
+	for _, plan := range res.PrivateHostPlans {
+		if !isPrivateHostPlanMatched(d, plan) {
+			continue
+		}
+		ids = append(ids, plan.ID.String())
+		plans = append(plans, map[string]interface{}{
+			"id":           plan.ID.String(),
+			"name":         plan.Name,
+			"availability": string(plan.Availability),
+			"class":        plan.Class,
+			"core":         plan.CPU,
+			"memory":       plan.GetMemoryGB(),
+		})
+	}
+	return setPlanDataSourceResourceData(d, client, "private-host-plans", ids, plans)
+}
+
+func isPrivateHostPlanMatched(d resourceValueGettable, plan *sacloud.PrivateHostPlan) bool {
+	return isPlanAvailabilityMatched(d, plan.Availability) &&
+		isPlanStringValueMatched(d, "class", plan.Class) &&
+		isPlanIntValueMatched(d, "core", plan.CPU) &&
+		isPlanIntValueMatched(d, "memory", plan.GetMemoryGB())
+}
@@ -0,0 +1,45 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourcePrivateHostPlans_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_private_host_plans.foobar"
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSakuraCloudDataSourcePrivateHostPlans_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "plans.0.class", "dynamic"),
+					resource.TestCheckResourceAttrSet(resourceName, "plans.0.core"),
+					resource.TestCheckResourceAttrSet(resourceName, "plans.0.memory"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourcePrivateHostPlans_basic = `
+data "sakuracloud_private_host_plans" "foobar" {
+  class = "dynamic"
+}`
@@ -356,7 +356,11 @@ func dataSourceSakuraCloudProxyLBRead(ctx context.Context, d *schema.ResourceDat
 		return filterNoResultErr()
 	}
 
-	targets := res.ProxyLBs
-	d.SetId(targets[0].ID.String())
-	return setProxyLBResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.ProxyLBs)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.ProxyLB)
+	d.SetId(data.ID.String())
+	return setProxyLBResourceData(ctx, d, client, data)
 }
@@ -30,7 +30,7 @@ func dataSourceSakuraCloudServer() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudServerRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Availability", "InterfaceDriver"}}),
 			"name":         schemaDataSourceName(resourceName),
 			"core": {
 				Type:        schema.TypeInt,
@@ -158,7 +158,11 @@ func dataSourceSakuraCloudServerRead(ctx context.Context, d *schema.ResourceData
 		return filterNoResultErr()
 	}
 
-	targets := res.Servers
-	d.SetId(targets[0].ID.String())
-	return setServerResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.Servers)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.Server)
+	d.SetId(data.ID.String())
+	return setServerResourceData(ctx, d, client, data)
 }
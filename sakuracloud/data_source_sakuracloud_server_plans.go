@@ -0,0 +1,134 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func dataSourceSakuraCloudServerPlans() *schema.Resource {
+	resourceName := "Server"
+	generations := []int{int(types.PlanGenerations.G100), int(types.PlanGenerations.G200)}
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudServerPlansRead,
+
+		Schema: map[string]*schema.Schema{
+			"core": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of virtual CPUs used for filtering",
+			},
+			"memory": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The size of memory in GiB used for filtering",
+			},
+			"commitment": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.CommitmentStrings, false)),
+				Description: descf(
+					"The policy of how to allocate virtual CPUs used for filtering. This must be one of [%s]",
+					types.CommitmentStrings,
+				),
+			},
+			"generation": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntInSlice(generations)),
+				Description: descf(
+					"The generation of the server plan used for filtering. This must be one of [%s]",
+					generations,
+				),
+			},
+			"available_only": schemaDataSourcePlanAvailableOnly(),
+			"ids":            schemaDataSourcePlanIDs(resourceName),
+			"plans": schemaDataSourcePlans(resourceName, map[string]*schema.Schema{
+				"core": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of virtual CPUs",
+				},
+				"memory": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The size of memory in GiB",
+				},
+				"commitment": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The policy of how to allocate virtual CPUs to the server",
+				},
+				"generation": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The generation of the server plan",
+				},
+			}),
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudServerPlansRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	planOp := sacloud.NewServerPlanOp(client)
+	res, err := planOp.Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return diag.Errorf("could not find SakuraCloud ServerPlan resources: %s", err)
+	}
+
+	var ids []string
+	var plans []interface{}
+	for _, plan := range res.ServerPlans {
+		if !isServerPlanMatched(d, plan) {
+			continue
+		}
+		ids = append(ids, plan.ID.String())
+		plans = append(plans, flattenServerPlan(plan))
+	}
+	return setPlanDataSourceResourceData(d, client, "server-plans", ids, plans)
+}
+
+func isServerPlanMatched(d resourceValueGettable, plan *sacloud.ServerPlan) bool {
+	return isPlanAvailabilityMatched(d, plan.Availability) &&
+		isPlanIntValueMatched(d, "core", plan.CPU) &&
+		isPlanIntValueMatched(d, "memory", plan.GetMemoryGB()) &&
+		isPlanStringValueMatched(d, "commitment", plan.Commitment.String()) &&
+		isPlanIntValueMatched(d, "generation", int(plan.Generation))
+}
+
+func flattenServerPlan(plan *sacloud.ServerPlan) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           plan.ID.String(),
+		"name":         plan.Name,
+		"availability": string(plan.Availability),
+		"core":         plan.CPU,
+		"memory":       plan.GetMemoryGB(),
+		"commitment":   plan.Commitment.String(),
+		"generation":   int(plan.Generation),
+	}
+}
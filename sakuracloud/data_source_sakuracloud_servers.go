@@ -0,0 +1,96 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func dataSourceSakuraCloudServers() *schema.Resource {
+	resourceName := "Server"
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudServersRead,
+
+		Schema: pluralDataSourceSchema(resourceName, "servers", filterSchemaOption{conditionNames: []string{"Availability", "InterfaceDriver"}}, map[string]*schema.Schema{
+			"core": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of virtual CPUs",
+			},
+			"memory": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of memory in GiB",
+			},
+			"disks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of disk id connected to the server",
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address assigned to the first network interface",
+			},
+			"instance_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the server instance",
+			},
+		}),
+	}
+}
+
+func dataSourceSakuraCloudServersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	targets, zones, err := findPluralTargets(ctx, d, client, zone, func(ctx context.Context, zone string, condition *sacloud.FindCondition) ([]pluralTarget, error) {
+		res, err := sacloud.NewServerOp(client).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		var targets []pluralTarget
+		for _, v := range res.Servers {
+			targets = append(targets, v)
+		}
+		return targets, nil
+	})
+	if err != nil {
+		return diag.Errorf("could not find SakuraCloud Server resources: %s", err)
+	}
+
+	var items []interface{}
+	for _, t := range targets {
+		data := t.(*sacloud.Server)
+		item := flattenPluralTarget(data, data.Description, data.Tags)
+		ip, _, _, _ := flattenServerNetworkInfo(data)
+		item["core"] = data.CPU
+		item["memory"] = data.GetMemoryGB()
+		item["disks"] = flattenServerConnectedDiskIDs(data)
+		item["ip_address"] = ip
+		item["instance_status"] = string(data.InstanceStatus)
+		items = append(items, item)
+	}
+	return setPluralDataSourceResourceData(d, client, "servers", targets, zones, items)
+}
@@ -0,0 +1,60 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDataSourceServers_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_servers.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceServers_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ids.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "servers.0.name", rand+"-0"),
+					resource.TestCheckResourceAttr(resourceName, "servers.0.core", "1"),
+					resource.TestCheckResourceAttr(resourceName, "servers.0.memory", "1"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceServers_basic = `
+resource "sakuracloud_server" "foobar" {
+  count = 2
+  name  = "{{ .arg0 }}-${count.index}"
+  tags  = ["{{ .arg0 }}"]
+  force_shutdown = true
+}
+
+data "sakuracloud_servers" "foobar" {
+  filter {
+    tags = ["{{ .arg0 }}"]
+  }
+
+  depends_on = [sakuracloud_server.foobar]
+}
+`
@@ -0,0 +1,91 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSakuraCloudServiceClass() *schema.Resource {
+	resourceName := "ServiceClass"
+	keys := []string{"service_class_path", "service_class_name"}
+
+	s := map[string]*schema.Schema{
+		"service_class_path": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: keys,
+			Description:  "The path of the service class (e.g. `cloud/plan/1`)",
+		},
+		"service_class_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: keys,
+			Description:  "The name of the service class (e.g. `plan/1`)",
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The display name of the service class",
+		},
+		"public": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "The flag to indicate whether the service class is public",
+		},
+		"zone": schemaDataSourceZone(resourceName),
+	}
+	for k, v := range schemaDataSourceServiceClassPrice() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudServiceClassRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceSakuraCloudServiceClassRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	key, value := "ServiceClassPath", d.Get("service_class_path").(string)
+	if v, ok := d.GetOk("service_class_name"); ok {
+		key, value = "ServiceClassName", v.(string)
+	}
+
+	data, err := findServiceClass(ctx, client, zone, key, value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(data.ID.String())
+	d.Set("service_class_path", data.ServiceClassPath) // nolint
+	d.Set("service_class_name", data.ServiceClassName) // nolint
+	d.Set("display_name", data.DisplayName)            // nolint
+	d.Set("public", data.IsPublic)                     // nolint
+	for k, v := range flattenServiceClassPrice(data.Price) {
+		d.Set(k, v) // nolint
+	}
+	d.Set("zone", getZone(d, client)) // nolint
+	return nil
+}
@@ -29,7 +29,7 @@ func dataSourceSakuraCloudSimpleMonitor() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudSimpleMonitorRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Target"}}),
 			"target": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -189,7 +189,11 @@ func dataSourceSakuraCloudSimpleMonitorRead(ctx context.Context, d *schema.Resou
 		return filterNoResultErr()
 	}
 
-	targets := res.SimpleMonitors
-	d.SetId(targets[0].ID.String())
-	return setSimpleMonitorResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.SimpleMonitors)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.SimpleMonitor)
+	d.SetId(data.ID.String())
+	return setSimpleMonitorResourceData(ctx, d, client, data)
 }
@@ -66,7 +66,11 @@ func dataSourceSakuraCloudSSHKeyRead(ctx context.Context, d *schema.ResourceData
 		return filterNoResultErr()
 	}
 
-	targets := res.SSHKeys
-	d.SetId(targets[0].ID.String())
-	return setSSHKeyResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.SSHKeys)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.SSHKey)
+	d.SetId(data.ID.String())
+	return setSSHKeyResourceData(ctx, d, client, data)
 }
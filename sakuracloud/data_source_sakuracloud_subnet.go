@@ -81,13 +81,12 @@ func dataSourceSakuraCloudSubnetRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	internetOp := sacloud.NewInternetOp(client)
 	subnetOp := sacloud.NewSubnetOp(client)
 
 	internetID := expandSakuraCloudID(d, "internet_id")
 	subnetIndex := d.Get("index").(int)
 
-	res, err := internetOp.Read(ctx, zone, internetID)
+	res, err := readInternet(ctx, client, zone, internetID)
 	if err != nil {
 		return diag.Errorf("could not find SakuraCloud Internet[%d]: %s", internetID, err)
 	}
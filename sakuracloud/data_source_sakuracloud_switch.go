@@ -28,7 +28,7 @@ func dataSourceSakuraCloudSwitch() *schema.Resource {
 		ReadContext: dataSourceSakuraCloudSwitchRead,
 
 		Schema: map[string]*schema.Schema{
-			filterAttrName: filterSchema(&filterSchemaOption{}),
+			filterAttrName: filterSchema(&filterSchemaOption{conditionNames: []string{"Scope"}}),
 			"name":         schemaDataSourceName(resourceName),
 			"icon_id":      schemaDataSourceIconID(resourceName),
 			"description":  schemaDataSourceDescription(resourceName),
@@ -66,7 +66,11 @@ func dataSourceSakuraCloudSwitchRead(ctx context.Context, d *schema.ResourceData
 		return filterNoResultErr()
 	}
 
-	targets := res.Switches
-	d.SetId(targets[0].ID.String())
-	return setSwitchResourceData(ctx, d, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.Switches)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.Switch)
+	d.SetId(data.ID.String())
+	return setSwitchResourceData(ctx, d, client, data)
 }
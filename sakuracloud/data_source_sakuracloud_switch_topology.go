@@ -0,0 +1,281 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+const (
+	switchTopologyTypeServer        = "server"
+	switchTopologyTypeVPCRouter     = "vpc_router"
+	switchTopologyTypeLoadBalancer  = "load_balancer"
+	switchTopologyTypeDatabase      = "database"
+	switchTopologyTypeNFS           = "nfs"
+	switchTopologyTypeMobileGateway = "mobile_gateway"
+)
+
+var switchTopologyTypes = []string{
+	switchTopologyTypeServer,
+	switchTopologyTypeVPCRouter,
+	switchTopologyTypeLoadBalancer,
+	switchTopologyTypeDatabase,
+	switchTopologyTypeNFS,
+	switchTopologyTypeMobileGateway,
+}
+
+func dataSourceSakuraCloudSwitchTopology() *schema.Resource {
+	resourceName := "Switch"
+	return &schema.Resource{
+		ReadContext: dataSourceSakuraCloudSwitchTopologyRead,
+
+		Schema: map[string]*schema.Schema{
+			"switch_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Switch to list the connected interfaces",
+			},
+			"interfaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: descf("The type of the resource which has the interface. This will be one of [%s]", switchTopologyTypes),
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the resource which has the interface",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the resource which has the interface",
+						},
+						"nic_index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The index of the interface in the resource",
+						},
+						"ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "A list of IP address assigned to the interface. This includes virtual IP addresses and IP aliases",
+						},
+						"mac_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The MAC address of the interface",
+						},
+					},
+				},
+				Description: "A list of the interfaces connected to the Switch",
+			},
+			"zone": schemaDataSourceZone(resourceName),
+		},
+	}
+}
+
+func dataSourceSakuraCloudSwitchTopologyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	swID := sakuraCloudID(d.Get("switch_id").(string))
+	swOp := sacloud.NewSwitchOp(client)
+	sw, err := swOp.Read(ctx, zone, swID)
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Switch[%s]: %s", swID, err)
+	}
+
+	interfaces, err := findSwitchTopologyInterfaces(ctx, client, zone, sw)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(sw.ID.String())
+	d.Set("zone", getZone(d, client)) // nolint
+	return diag.FromErr(d.Set("interfaces", interfaces))
+}
+
+// findSwitchTopologyInterfaces スイッチに接続されているサーバ/アプライアンスのNICを返す
+func findSwitchTopologyInterfaces(ctx context.Context, client *APIClient, zone string, sw *sacloud.Switch) ([]interface{}, error) {
+	var interfaces []interface{}
+	if sw.ServerCount > 0 {
+		servers, err := sacloud.NewSwitchOp(client).GetServers(ctx, zone, sw.ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not find SakuraCloud Servers connected to Switch[%s]: %s", sw.ID, err)
+		}
+		for _, server := range servers.Servers {
+			interfaces = append(interfaces, flattenSwitchTopologyServer(sw.ID, server)...)
+		}
+	}
+
+	appliances, err := findSwitchTopologyAppliances(ctx, client, zone, sw.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find SakuraCloud Appliances connected to Switch[%s]: %s", sw.ID, err)
+	}
+	return append(interfaces, appliances...), nil
+}
+
+func findSwitchTopologyAppliances(ctx context.Context, client *APIClient, zone string, swID types.ID) ([]interface{}, error) {
+	var results []interface{}
+
+	vpcRouters, err := sacloud.NewVPCRouterOp(client).Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vpcRouters.VPCRouters {
+		results = append(results, flattenSwitchTopologyVPCRouter(swID, v)...)
+	}
+
+	loadBalancers, err := sacloud.NewLoadBalancerOp(client).Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return nil, err
+	}
+	for _, lb := range loadBalancers.LoadBalancers {
+		if lb.SwitchID == swID {
+			ipAddresses := append([]string{}, lb.IPAddresses...)
+			for _, vip := range lb.VirtualIPAddresses {
+				ipAddresses = append(ipAddresses, vip.VirtualIPAddress)
+			}
+			results = append(results, flattenSwitchTopologyAppliance(switchTopologyTypeLoadBalancer, lb.ID, lb.Name, ipAddresses, lb.Interfaces))
+		}
+	}
+
+	databases, err := sacloud.NewDatabaseOp(client).Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return nil, err
+	}
+	for _, db := range databases.Databases {
+		if db.SwitchID == swID {
+			results = append(results, flattenSwitchTopologyAppliance(switchTopologyTypeDatabase, db.ID, db.Name, db.IPAddresses, db.Interfaces))
+		}
+	}
+
+	nfsList, err := sacloud.NewNFSOp(client).Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return nil, err
+	}
+	for _, nfs := range nfsList.NFS {
+		if nfs.SwitchID == swID {
+			results = append(results, flattenSwitchTopologyAppliance(switchTopologyTypeNFS, nfs.ID, nfs.Name, nfs.IPAddresses, nfs.Interfaces))
+		}
+	}
+
+	mobileGateways, err := sacloud.NewMobileGatewayOp(client).Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return nil, err
+	}
+	for _, mgw := range mobileGateways.MobileGateways {
+		results = append(results, flattenSwitchTopologyMobileGateway(swID, mgw)...)
+	}
+
+	return results, nil
+}
+
+func flattenSwitchTopologyInterface(resourceType string, id types.ID, name string, index int, ipAddresses []string, macAddress string) interface{} {
+	var addresses []string
+	for _, ip := range ipAddresses {
+		if ip != "" {
+			addresses = append(addresses, ip)
+		}
+	}
+	return map[string]interface{}{
+		"resource_type": resourceType,
+		"resource_id":   id.String(),
+		"resource_name": name,
+		"nic_index":     index,
+		"ip_addresses":  addresses,
+		"mac_address":   strings.ToLower(macAddress),
+	}
+}
+
+func flattenSwitchTopologyServer(swID types.ID, server *sacloud.Server) []interface{} {
+	var results []interface{}
+	for i, nic := range server.Interfaces {
+		if nic.SwitchID != swID {
+			continue
+		}
+		ip := nic.UserIPAddress
+		if ip == "" {
+			ip = nic.IPAddress
+		}
+		results = append(results, flattenSwitchTopologyInterface(switchTopologyTypeServer, server.ID, server.Name, i, []string{ip}, nic.MACAddress))
+	}
+	return results
+}
+
+func flattenSwitchTopologyVPCRouter(swID types.ID, vpcRouter *sacloud.VPCRouter) []interface{} {
+	var results []interface{}
+	for _, nic := range vpcRouter.Interfaces {
+		if nic.SwitchID != swID {
+			continue
+		}
+		var ipAddresses []string
+		if vpcRouter.Settings != nil {
+			for _, setting := range vpcRouter.Settings.Interfaces {
+				if setting.Index != nic.Index {
+					continue
+				}
+				ipAddresses = append(ipAddresses, setting.VirtualIPAddress)
+				ipAddresses = append(ipAddresses, setting.IPAddress...)
+				ipAddresses = append(ipAddresses, setting.IPAliases...)
+			}
+		}
+		results = append(results, flattenSwitchTopologyInterface(switchTopologyTypeVPCRouter, vpcRouter.ID, vpcRouter.Name, nic.Index, ipAddresses, nic.MACAddress))
+	}
+	return results
+}
+
+func flattenSwitchTopologyMobileGateway(swID types.ID, mgw *sacloud.MobileGateway) []interface{} {
+	var results []interface{}
+	for _, nic := range mgw.Interfaces {
+		if nic.SwitchID != swID {
+			continue
+		}
+		var ipAddresses []string
+		for _, setting := range mgw.InterfaceSettings {
+			if setting.Index == nic.Index {
+				ipAddresses = append(ipAddresses, setting.IPAddress...)
+			}
+		}
+		results = append(results, flattenSwitchTopologyInterface(switchTopologyTypeMobileGateway, mgw.ID, mgw.Name, nic.Index, ipAddresses, nic.MACAddress))
+	}
+	return results
+}
+
+// flattenSwitchTopologyAppliance スイッチに接続するNICを1つだけ持つアプライアンス(ロードバランサ/データベース/NFS)のNICを返す
+func flattenSwitchTopologyAppliance(resourceType string, id types.ID, name string, ipAddresses []string, interfaces []*sacloud.InterfaceView) interface{} {
+	macAddress := ""
+	if len(interfaces) > 0 {
+		macAddress = interfaces[0].MACAddress
+	}
+	return flattenSwitchTopologyInterface(resourceType, id, name, 0, ipAddresses, macAddress)
+}
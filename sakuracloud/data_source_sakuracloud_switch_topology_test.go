@@ -0,0 +1,142 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSakuraCloudDataSourceSwitchTopology_basic(t *testing.T) {
+	resourceName := "data.sakuracloud_switch_topology.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDataSourceSwitchTopology_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDataSourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "interfaces.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "interfaces.0.resource_type", "server"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "interfaces.0.resource_id",
+						"sakuracloud_server.foobar", "id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "interfaces.0.nic_index", "0"),
+					resource.TestCheckResourceAttr(resourceName, "interfaces.0.ip_addresses.0", "192.168.0.11"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "interfaces.0.mac_address",
+						"sakuracloud_server.foobar", "network_interface.0.mac_address",
+					),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDataSourceSwitchTopology_basic = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "{{ .arg0 }}"
+  network_interface {
+    upstream        = sakuracloud_switch.foobar.id
+    user_ip_address = "192.168.0.11"
+  }
+  force_shutdown = true
+}
+
+data "sakuracloud_switch_topology" "foobar" {
+  switch_id  = sakuracloud_switch.foobar.id
+  depends_on = [sakuracloud_server.foobar]
+}
+`
+
+func TestDataSourceSwitchTopology_flatten(t *testing.T) {
+	swID := sakuraCloudID("100000000001")
+	otherID := sakuraCloudID("100000000002")
+
+	server := &sacloud.Server{
+		ID:   sakuraCloudID("200000000001"),
+		Name: "server",
+		Interfaces: []*sacloud.InterfaceView{
+			{SwitchID: otherID, IPAddress: "192.0.2.1", MACAddress: "9C:A3:BA:00:00:01"},
+			{SwitchID: swID, UserIPAddress: "192.168.0.11", MACAddress: "9C:A3:BA:00:00:02"},
+		},
+	}
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"resource_type": "server",
+			"resource_id":   "200000000001",
+			"resource_name": "server",
+			"nic_index":     1,
+			"ip_addresses":  []string{"192.168.0.11"},
+			"mac_address":   "9c:a3:ba:00:00:02",
+		},
+	}, flattenSwitchTopologyServer(swID, server))
+
+	vpcRouter := &sacloud.VPCRouter{
+		ID:   sakuraCloudID("200000000002"),
+		Name: "vpc-router",
+		Interfaces: []*sacloud.VPCRouterInterface{
+			{SwitchID: otherID, Index: 0},
+			{SwitchID: swID, Index: 1, MACAddress: "9C:A3:BA:00:00:03"},
+		},
+		Settings: &sacloud.VPCRouterSetting{
+			Interfaces: []*sacloud.VPCRouterInterfaceSetting{
+				{Index: 1, VirtualIPAddress: "192.168.0.1", IPAddress: []string{"192.168.0.2", "192.168.0.3"}, IPAliases: []string{"192.168.0.4"}},
+			},
+		},
+	}
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"resource_type": "vpc_router",
+			"resource_id":   "200000000002",
+			"resource_name": "vpc-router",
+			"nic_index":     1,
+			"ip_addresses":  []string{"192.168.0.1", "192.168.0.2", "192.168.0.3", "192.168.0.4"},
+			"mac_address":   "9c:a3:ba:00:00:03",
+		},
+	}, flattenSwitchTopologyVPCRouter(swID, vpcRouter))
+
+	mgw := &sacloud.MobileGateway{
+		ID:   sakuraCloudID("200000000003"),
+		Name: "mobile-gateway",
+		Interfaces: []*sacloud.MobileGatewayInterface{
+			{SwitchID: otherID, Index: 0},
+		},
+	}
+	assert.Empty(t, flattenSwitchTopologyMobileGateway(swID, mgw))
+
+	assert.Equal(t, map[string]interface{}{
+		"resource_type": "database",
+		"resource_id":   "200000000004",
+		"resource_name": "database",
+		"nic_index":     0,
+		"ip_addresses":  []string{"192.168.0.21"},
+		"mac_address":   "9c:a3:ba:00:00:04",
+	}, flattenSwitchTopologyAppliance(
+		switchTopologyTypeDatabase, sakuraCloudID("200000000004"), "database",
+		[]string{"192.168.0.21"}, []*sacloud.InterfaceView{{MACAddress: "9C:A3:BA:00:00:04"}},
+	))
+}
@@ -479,7 +479,11 @@ func dataSourceSakuraCloudVPCRouterRead(ctx context.Context, d *schema.ResourceD
 		return filterNoResultErr()
 	}
 
-	targets := res.VPCRouters
-	d.SetId(targets[0].ID.String())
-	return setVPCRouterResourceData(ctx, d, zone, client, targets[0])
+	target, diags := selectFilteredTarget(d, res.VPCRouters)
+	if target == nil {
+		return diags
+	}
+	data := target.(*sacloud.VPCRouter)
+	d.SetId(data.ID.String())
+	return setVPCRouterResourceData(ctx, d, zone, client, data)
 }
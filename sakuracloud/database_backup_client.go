@@ -0,0 +1,193 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+// databaseBackupAPI データベースアプライアンスのバックアップ操作
+//
+// libsacloud v2ではバックアップの取得/復元/削除がサポートされていないため、
+// DatabaseOpのパス設定を元にAPIを直接呼び出して操作する
+type databaseBackupAPI interface {
+	Status(ctx context.Context, zone string, id types.ID) (*sacloud.DatabaseStatus, error)
+	List(ctx context.Context, zone string, id types.ID) ([]*sacloud.DatabaseBackupHistory, error)
+	Create(ctx context.Context, zone string, id types.ID) error
+	Restore(ctx context.Context, zone string, id types.ID, backupID string) error
+	Delete(ctx context.Context, zone string, id types.ID, backupID string) error
+}
+
+func newDatabaseBackupOp(client *APIClient) databaseBackupAPI {
+	return &databaseBackupOp{caller: client, dbOp: sacloud.NewDatabaseOp(client)}
+}
+
+// databaseBackupID バックアップ履歴を一意に識別するID(作成日時)を返す
+func databaseBackupID(history *sacloud.DatabaseBackupHistory) string {
+	return history.CreatedAt.Format(time.RFC3339)
+}
+
+func findDatabaseBackup(histories []*sacloud.DatabaseBackupHistory, backupID string) *sacloud.DatabaseBackupHistory {
+	for _, h := range histories {
+		if databaseBackupID(h) == backupID {
+			return h
+		}
+	}
+	return nil
+}
+
+// waitForDatabaseBackup バックアップを取得し、取得したバックアップが利用可能になるまで待つ
+func waitForDatabaseBackup(ctx context.Context, backupOp databaseBackupAPI, zone string, id types.ID) (*sacloud.DatabaseBackupHistory, error) {
+	before, err := backupOp.List(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := backupOp.Create(ctx, zone, id); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(sacloud.DefaultDBStatusPollingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			histories, err := backupOp.List(ctx, zone, id)
+			if err != nil {
+				return nil, err
+			}
+			for _, h := range histories {
+				if findDatabaseBackup(before, databaseBackupID(h)) != nil {
+					continue
+				}
+				availability := types.EAvailability(h.Availability)
+				switch {
+				case availability.IsAvailable():
+					return h, nil
+				case availability.IsFailed(), availability.IsDiscontinued():
+					return nil, fmt.Errorf("backup[%s] is %s", databaseBackupID(h), h.Availability)
+				}
+			}
+		}
+	}
+}
+
+// waitForDatabaseRestore バックアップから復元し、復元が完了するまで待つ
+//
+// 復元の完了はバックアップ履歴の復元日時が復元前から更新されたかで判定する。
+// アプライアンスが異常状態となった場合やバックアップが利用できなくなった場合は復元に失敗したと判定する
+func waitForDatabaseRestore(ctx context.Context, backupOp databaseBackupAPI, zone string, id types.ID, backupID string) (*sacloud.DatabaseBackupHistory, error) {
+	histories, err := backupOp.List(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	history := findDatabaseBackup(histories, backupID)
+	if history == nil {
+		return nil, fmt.Errorf("backup[%s] is not found", backupID)
+	}
+	recoveredAt := history.RecoveredAt
+
+	if err := backupOp.Restore(ctx, zone, id, backupID); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(sacloud.DefaultDBStatusPollingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+			status, err := backupOp.Status(ctx, zone, id)
+			if err != nil {
+				return nil, err
+			}
+			if status.IsFatal {
+				return nil, fmt.Errorf("restoring backup[%s] is failed: Database is in fatal status", backupID)
+			}
+			history := findDatabaseBackup(status.Backups, backupID)
+			if history == nil {
+				return nil, fmt.Errorf("backup[%s] is not found", backupID)
+			}
+			if history.RecoveredAt.After(recoveredAt) {
+				return history, nil
+			}
+			availability := types.EAvailability(history.Availability)
+			if availability.IsFailed() || availability.IsDiscontinued() {
+				return nil, fmt.Errorf("restoring backup[%s] is failed: backup is %s", backupID, history.Availability)
+			}
+		}
+	}
+}
+
+type databaseBackupOp struct {
+	caller sacloud.APICaller
+	dbOp   sacloud.DatabaseAPI
+}
+
+func (o *databaseBackupOp) url(zone string, id types.ID, backupID string) (string, error) {
+	dbOp, ok := o.dbOp.(*sacloud.DatabaseOp)
+	if !ok {
+		// fakeドライバではバックアップの取得/復元/削除は未サポート
+		return "", errors.New("database backup operations are not supported by the current API driver")
+	}
+	paths := []string{sacloud.SakuraCloudAPIRoot, zone, dbOp.PathSuffix, dbOp.PathName, id.String(), "database", "backup"}
+	if backupID != "" {
+		paths = append(paths, url.PathEscape(backupID))
+	}
+	return strings.Join(paths, "/"), nil
+}
+
+func (o *databaseBackupOp) do(ctx context.Context, method string, zone string, id types.ID, backupID string) error {
+	u, err := o.url(zone, id, backupID)
+	if err != nil {
+		return err
+	}
+	_, err = o.caller.Do(ctx, method, u, nil)
+	return err
+}
+
+func (o *databaseBackupOp) Status(ctx context.Context, zone string, id types.ID) (*sacloud.DatabaseStatus, error) {
+	return o.dbOp.Status(ctx, zone, id)
+}
+
+func (o *databaseBackupOp) List(ctx context.Context, zone string, id types.ID) ([]*sacloud.DatabaseBackupHistory, error) {
+	status, err := o.Status(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	return status.Backups, nil
+}
+
+func (o *databaseBackupOp) Create(ctx context.Context, zone string, id types.ID) error {
+	return o.do(ctx, "POST", zone, id, "")
+}
+
+func (o *databaseBackupOp) Restore(ctx context.Context, zone string, id types.ID, backupID string) error {
+	return o.do(ctx, "PUT", zone, id, backupID)
+}
+
+func (o *databaseBackupOp) Delete(ctx context.Context, zone string, id types.ID, backupID string) error {
+	return o.do(ctx, "DELETE", zone, id, backupID)
+}
@@ -0,0 +1,221 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/fake"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// databaseBackupTestOp Status/Listの呼び出しごとに指定のバックアップ履歴を返すdatabaseBackupAPI
+type databaseBackupTestOp struct {
+	histories [][]*sacloud.DatabaseBackupHistory
+	fatal     bool // trueの場合、2回目以降の呼び出しでアプライアンスの異常状態を返す
+	listCount int
+}
+
+func (o *databaseBackupTestOp) Status(context.Context, string, types.ID) (*sacloud.DatabaseStatus, error) {
+	count := o.listCount
+	o.listCount++
+	i := count
+	if i >= len(o.histories) {
+		i = len(o.histories) - 1
+	}
+	return &sacloud.DatabaseStatus{
+		IsFatal: o.fatal && count > 0,
+		Backups: o.histories[i],
+	}, nil
+}
+
+func (o *databaseBackupTestOp) List(ctx context.Context, zone string, id types.ID) ([]*sacloud.DatabaseBackupHistory, error) {
+	status, err := o.Status(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	return status.Backups, nil
+}
+
+func (o *databaseBackupTestOp) Create(context.Context, string, types.ID) error { return nil }
+
+func (o *databaseBackupTestOp) Restore(context.Context, string, types.ID, string) error { return nil }
+
+func (o *databaseBackupTestOp) Delete(context.Context, string, types.ID, string) error { return nil }
+
+func TestDatabaseBackupClient_waitForDatabaseBackup(t *testing.T) {
+	setupFakeDefaultsForUnitTest(t)
+
+	existing := &sacloud.DatabaseBackupHistory{
+		CreatedAt:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Availability: string(types.Availabilities.Available),
+	}
+	created := func(availability types.EAvailability) *sacloud.DatabaseBackupHistory {
+		return &sacloud.DatabaseBackupHistory{
+			CreatedAt:    time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			Availability: string(availability),
+		}
+	}
+
+	cases := []struct {
+		msg       string
+		histories [][]*sacloud.DatabaseBackupHistory
+		expectErr bool
+	}{
+		{
+			msg: "available",
+			histories: [][]*sacloud.DatabaseBackupHistory{
+				{existing},
+				{existing, created(types.Availabilities.Migrating)},
+				{existing, created(types.Availabilities.Available)},
+			},
+		},
+		{
+			msg: "failed",
+			histories: [][]*sacloud.DatabaseBackupHistory{
+				{existing},
+				{existing, created(types.Availabilities.Failed)},
+			},
+			expectErr: true,
+		},
+		{
+			msg: "discontinued",
+			histories: [][]*sacloud.DatabaseBackupHistory{
+				{existing},
+				{existing, created(types.Availabilities.Discontinued)},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		backup, err := waitForDatabaseBackup(ctx, &databaseBackupTestOp{histories: tc.histories}, "is1a", types.ID(1))
+		cancel()
+
+		if tc.expectErr {
+			assert.Error(t, err, tc.msg)
+			assert.NotEqual(t, context.DeadlineExceeded, err, tc.msg)
+			continue
+		}
+		if assert.NoError(t, err, tc.msg) {
+			assert.Equal(t, "2021-01-02T00:00:00Z", databaseBackupID(backup), tc.msg)
+		}
+	}
+}
+
+func TestDatabaseBackupClient_waitForDatabaseRestore(t *testing.T) {
+	setupFakeDefaultsForUnitTest(t)
+
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := func(recoveredAt time.Time) []*sacloud.DatabaseBackupHistory {
+		return []*sacloud.DatabaseBackupHistory{
+			{
+				CreatedAt:    createdAt,
+				Availability: string(types.Availabilities.Available),
+				RecoveredAt:  recoveredAt,
+			},
+		}
+	}
+	// サーバ側の時計はローカルより遅れている場合がある
+	previous := time.Now().Add(-time.Hour)
+	recovered := previous.Add(time.Minute)
+
+	op := &databaseBackupTestOp{
+		histories: [][]*sacloud.DatabaseBackupHistory{
+			history(previous),
+			history(previous),
+			history(recovered),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	backup, err := waitForDatabaseRestore(ctx, op, "is1a", types.ID(1), databaseBackupID(history(previous)[0]))
+	if assert.NoError(t, err) {
+		assert.Equal(t, recovered, backup.RecoveredAt)
+		assert.Equal(t, 3, op.listCount)
+	}
+}
+
+func TestDatabaseBackupClient_waitForDatabaseRestoreFailed(t *testing.T) {
+	setupFakeDefaultsForUnitTest(t)
+
+	createdAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	backupID := "2021-01-01T00:00:00Z"
+	history := func(availability types.EAvailability) []*sacloud.DatabaseBackupHistory {
+		return []*sacloud.DatabaseBackupHistory{
+			{
+				CreatedAt:    createdAt,
+				Availability: string(availability),
+			},
+		}
+	}
+
+	cases := []struct {
+		msg string
+		op  *databaseBackupTestOp
+	}{
+		{
+			msg: "fatal",
+			op: &databaseBackupTestOp{
+				histories: [][]*sacloud.DatabaseBackupHistory{
+					history(types.Availabilities.Available),
+				},
+				fatal: true,
+			},
+		},
+		{
+			msg: "backup is failed",
+			op: &databaseBackupTestOp{
+				histories: [][]*sacloud.DatabaseBackupHistory{
+					history(types.Availabilities.Available),
+					history(types.Availabilities.Migrating),
+					history(types.Availabilities.Failed),
+				},
+			},
+		},
+		{
+			msg: "backup is discontinued",
+			op: &databaseBackupTestOp{
+				histories: [][]*sacloud.DatabaseBackupHistory{
+					history(types.Availabilities.Available),
+					history(types.Availabilities.Discontinued),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := waitForDatabaseRestore(ctx, tc.op, "is1a", types.ID(1), backupID)
+		cancel()
+
+		assert.Error(t, err, tc.msg)
+		assert.NotEqual(t, context.DeadlineExceeded, err, tc.msg)
+	}
+}
+
+func TestDatabaseBackupClient_notSupportedWithFakeDriver(t *testing.T) {
+	op := &databaseBackupOp{dbOp: fake.NewDatabaseOp()}
+
+	err := op.Create(context.Background(), "is1a", types.ID(1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,174 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	databaseBuilder "github.com/sacloud/libsacloud/v2/helper/builder/database"
+	"github.com/sacloud/libsacloud/v2/helper/power"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+// databaseMigration プラン/バージョン変更のためのデータベースアプライアンスの移行処理
+//
+// 以下の順で処理を行う
+//   - 移行元を停止
+//   - 移行元をクローン元(SourceID)として、同じネットワーク設定で新しいアプライアンスを作成
+//   - 移行元に接続されていたリードレプリカを新しいアプライアンスへ再接続
+//   - 移行元を削除
+//
+// データベース種別が異なる場合はクローンできずデータを引き継げないため移行は行わない(database_typeの変更は再作成となる)。
+//
+// 新しいアプライアンスの作成に失敗した場合は移行元を再度起動する。
+// 新しいアプライアンスの作成後に失敗した場合は、呼び出し元でIDを差し替えられるように新しいアプライアンスを返す
+type databaseMigration struct {
+	client *APIClient
+	zone   string
+	dbOp   sacloud.DatabaseAPI
+	diags  diag.Diagnostics
+}
+
+func newDatabaseMigration(client *APIClient, zone string) *databaseMigration {
+	return &databaseMigration{
+		client: client,
+		zone:   zone,
+		dbOp:   sacloud.NewDatabaseOp(client),
+	}
+}
+
+func (m *databaseMigration) progress(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	log.Printf("[INFO] %s", msg)
+	m.diags = append(m.diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Database migration",
+		Detail:   msg,
+	})
+}
+
+func (m *databaseMigration) failed(db *sacloud.Database, format string, args ...interface{}) (*sacloud.Database, diag.Diagnostics) {
+	return db, append(m.diags, diag.Errorf(format, args...)...)
+}
+
+// Migrate 移行を行い、新しいアプライアンスを返す
+//
+// 新しいアプライアンスの作成後に失敗した場合はエラーを含むdiagsと共に新しいアプライアンスを返す
+func (m *databaseMigration) Migrate(ctx context.Context, d *schema.ResourceData, source *sacloud.Database) (*sacloud.Database, diag.Diagnostics) {
+	if !source.InstanceStatus.IsUp() {
+		return m.failed(nil, "migrating SakuraCloud Database[%s] is failed: Database must be running", source.ID)
+	}
+
+	if flattenDatabaseType(source) != d.Get("database_type").(string) {
+		return m.failed(nil, "migrating SakuraCloud Database[%s] is failed: database_type can't be changed by migration", source.ID)
+	}
+
+	replicas, err := m.findReadReplicas(ctx, source.ID)
+	if err != nil {
+		return m.failed(nil, "finding read replicas of SakuraCloud Database[%s] is failed: %s", source.ID, err)
+	}
+
+	if err := power.ShutdownDatabase(ctx, m.dbOp, m.zone, source.ID, false); err != nil {
+		return m.failed(nil, "stopping SakuraCloud Database[%s] is failed: %s", source.ID, err)
+	}
+	m.progress("Database[%s] has been stopped", source.ID)
+
+	builder := expandDatabaseBuilder(d, m.client)
+	builder.Client = &databaseBuilder.APIClient{Database: m.dbOp}
+	builder.SourceID = source.ID
+	db, err := builder.Build(ctx, m.zone)
+	if err != nil {
+		if db != nil {
+			m.progress("Database[%s] created while migrating is left, please delete it manually", db.ID)
+		}
+		if err := power.BootDatabase(ctx, m.dbOp, m.zone, source.ID); err != nil {
+			m.progress("booting Database[%s] is failed: %s", source.ID, err)
+		} else {
+			m.progress("Database[%s] has been booted again", source.ID)
+		}
+		return m.failed(nil, "creating SakuraCloud Database from Database[%s] is failed: %s", source.ID, err)
+	}
+	m.progress("Database[%s] has been cloned from Database[%s] with plan %q", db.ID, source.ID, types.DatabasePlanNameMap[db.PlanID])
+
+	// HACK データベースアプライアンスの電源投入後すぐに他の操作(Updateなど)を行うと202(Accepted)が返ってくるものの無視される。
+	time.Sleep(m.client.databaseWaitAfterCreateDuration)
+
+	for _, replica := range replicas {
+		if err := m.reattachReadReplica(ctx, replica, db); err != nil {
+			m.progress("Database[%s] is left stopped, please delete it manually after re-attaching read replicas", source.ID)
+			return m.failed(db, "re-attaching read replica[%s] to SakuraCloud Database[%s] is failed: %s", replica.ID, db.ID, err)
+		}
+		m.progress("read replica[%s] has been re-attached to Database[%s]", replica.ID, db.ID)
+	}
+
+	if err := m.dbOp.Delete(ctx, m.zone, source.ID); err != nil {
+		m.progress("Database[%s] is left stopped, please delete it manually", source.ID)
+		return m.failed(db, "deleting SakuraCloud Database[%s] is failed: %s", source.ID, err)
+	}
+	m.progress("Database[%s] has been deleted", source.ID)
+
+	return db, m.diags
+}
+
+func (m *databaseMigration) findReadReplicas(ctx context.Context, masterID types.ID) ([]*sacloud.Database, error) {
+	searched, err := m.dbOp.Find(ctx, m.zone, &sacloud.FindCondition{})
+	if err != nil {
+		return nil, err
+	}
+	var replicas []*sacloud.Database
+	for _, db := range searched.Databases {
+		if isDatabaseReadReplicaOf(db, masterID) {
+			replicas = append(replicas, db)
+		}
+	}
+	return replicas, nil
+}
+
+func (m *databaseMigration) reattachReadReplica(ctx context.Context, replica, master *sacloud.Database) error {
+	sakuraMutexKV.Lock(replica.ID.String())
+	defer sakuraMutexKV.Unlock(replica.ID.String())
+
+	setting := *replica.ReplicationSetting
+	setting.ApplianceID = master.ID
+	setting.IPAddress = master.IPAddresses[0]
+	setting.Port = master.CommonSetting.ServicePort
+
+	_, err := m.dbOp.Update(ctx, m.zone, replica.ID, &sacloud.DatabaseUpdateRequest{
+		Name:               replica.Name,
+		Description:        replica.Description,
+		Tags:               replica.Tags,
+		IconID:             replica.IconID,
+		CommonSetting:      replica.CommonSetting,
+		BackupSetting:      replica.BackupSetting,
+		ReplicationSetting: &setting,
+		SettingsHash:       replica.SettingsHash,
+	})
+	if err != nil {
+		return err
+	}
+	return m.dbOp.Config(ctx, m.zone, replica.ID)
+}
+
+func isDatabaseReadReplicaOf(db *sacloud.Database, masterID types.ID) bool {
+	return db.ReplicationSetting != nil &&
+		db.ReplicationSetting.Model == types.DatabaseReplicationModels.AsyncReplica &&
+		db.ReplicationSetting.ApplianceID == masterID
+}
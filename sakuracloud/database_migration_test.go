@@ -0,0 +1,201 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/fake"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// databaseMigrationTestOp 移行処理の途中での失敗を再現するためのDatabaseAPI
+type databaseMigrationTestOp struct {
+	sacloud.DatabaseAPI
+	updateErr error
+	deleteErr error
+}
+
+func (o *databaseMigrationTestOp) Update(ctx context.Context, zone string, id types.ID, param *sacloud.DatabaseUpdateRequest) (*sacloud.Database, error) {
+	if o.updateErr != nil {
+		return nil, o.updateErr
+	}
+	return o.DatabaseAPI.Update(ctx, zone, id, param)
+}
+
+func (o *databaseMigrationTestOp) Delete(ctx context.Context, zone string, id types.ID) error {
+	if o.deleteErr != nil {
+		return o.deleteErr
+	}
+	return o.DatabaseAPI.Delete(ctx, zone, id)
+}
+
+func setupDatabaseMigrationTest(t *testing.T, op *databaseMigrationTestOp) (*databaseMigration, *sacloud.Database, *sacloud.Database) {
+	setupFakeDefaultsForUnitTest(t)
+
+	ctx := context.Background()
+	zone := "is1a"
+	op.DatabaseAPI = fake.NewDatabaseOp()
+
+	source, err := op.DatabaseAPI.Create(ctx, zone, &sacloud.DatabaseCreateRequest{
+		PlanID:         types.DatabasePlans.DB10GB,
+		SwitchID:       types.ID(123456789012),
+		IPAddresses:    []string{"192.168.11.11"},
+		NetworkMaskLen: 24,
+		DefaultRoute:   "192.168.11.1",
+		Conf: &sacloud.DatabaseRemarkDBConfCommon{
+			DatabaseName:    types.RDBMSVersions[types.RDBMSTypesPostgreSQL].Name,
+			DatabaseVersion: types.RDBMSVersions[types.RDBMSTypesPostgreSQL].Version,
+		},
+		CommonSetting: &sacloud.DatabaseSettingCommon{ServicePort: 5432},
+		Name:          "source",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replica, err := op.DatabaseAPI.Create(ctx, zone, &sacloud.DatabaseCreateRequest{
+		PlanID:         types.DatabasePlans.DB10GB,
+		SwitchID:       types.ID(123456789012),
+		IPAddresses:    []string{"192.168.11.12"},
+		NetworkMaskLen: 24,
+		DefaultRoute:   "192.168.11.1",
+		Conf:           source.Conf,
+		CommonSetting:  &sacloud.DatabaseSettingCommon{ServicePort: 5432},
+		ReplicationSetting: &sacloud.DatabaseReplicationSetting{
+			Model:       types.DatabaseReplicationModels.AsyncReplica,
+			ApplianceID: source.ID,
+			IPAddress:   source.IPAddresses[0],
+			Port:        5432,
+		},
+		Name: "replica",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !assert.Eventually(t, func() bool {
+		db, err := op.DatabaseAPI.Read(ctx, zone, source.ID)
+		return err == nil && db.InstanceStatus.IsUp()
+	}, time.Second, time.Millisecond) {
+		t.FailNow()
+	}
+	source, err = op.DatabaseAPI.Read(ctx, zone, source.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migration := &databaseMigration{
+		client: &APIClient{databaseWaitAfterCreateDuration: time.Millisecond},
+		zone:   zone,
+		dbOp:   op,
+	}
+	return migration, source, replica
+}
+
+func testDatabaseMigrationResourceData(t *testing.T, databaseType string) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, resourceSakuraCloudDatabase().Schema, map[string]interface{}{
+		"name":          "migrated",
+		"database_type": databaseType,
+		"plan":          "30g",
+		"username":      "defuser",
+		"password":      "password",
+		"network_interface": []interface{}{
+			map[string]interface{}{
+				"switch_id":  "123456789012",
+				"ip_address": "192.168.11.11",
+				"netmask":    24,
+				"gateway":    "192.168.11.1",
+				"port":       5432,
+			},
+		},
+	})
+}
+
+func TestDatabaseMigration_Migrate(t *testing.T) {
+	op := &databaseMigrationTestOp{}
+	migration, source, replica := setupDatabaseMigrationTest(t, op)
+	ctx := context.Background()
+
+	db, diags := migration.Migrate(ctx, testDatabaseMigrationResourceData(t, "postgres"), source)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	assert.NotEqual(t, source.ID, db.ID)
+	assert.Equal(t, types.DatabasePlans.DB30GB, db.PlanID)
+	assert.Equal(t, source.IPAddresses, db.IPAddresses)
+
+	_, err := op.Read(ctx, migration.zone, source.ID)
+	assert.True(t, sacloud.IsNotFoundError(err))
+
+	replica, err = op.Read(ctx, migration.zone, replica.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, db.ID, replica.ReplicationSetting.ApplianceID)
+}
+
+func TestDatabaseMigration_MigrateReturnsNewDatabaseOnFailure(t *testing.T) {
+	cases := []struct {
+		msg string
+		op  *databaseMigrationTestOp
+	}{
+		{
+			msg: "re-attaching read replica is failed",
+			op:  &databaseMigrationTestOp{updateErr: errors.New("dummy")},
+		},
+		{
+			msg: "deleting source is failed",
+			op:  &databaseMigrationTestOp{deleteErr: errors.New("dummy")},
+		},
+	}
+
+	for _, tc := range cases {
+		migration, source, _ := setupDatabaseMigrationTest(t, tc.op)
+		ctx := context.Background()
+
+		db, diags := migration.Migrate(ctx, testDatabaseMigrationResourceData(t, "postgres"), source)
+		assert.True(t, diags.HasError(), tc.msg)
+		if assert.NotNil(t, db, tc.msg) {
+			assert.NotEqual(t, source.ID, db.ID, tc.msg)
+		}
+
+		_, err := tc.op.Read(ctx, migration.zone, source.ID)
+		assert.NoError(t, err, tc.msg)
+	}
+}
+
+func TestDatabaseMigration_MigrateDatabaseType(t *testing.T) {
+	op := &databaseMigrationTestOp{}
+	migration, source, _ := setupDatabaseMigrationTest(t, op)
+	ctx := context.Background()
+
+	db, diags := migration.Migrate(ctx, testDatabaseMigrationResourceData(t, "mariadb"), source)
+	assert.True(t, diags.HasError())
+	assert.Nil(t, db)
+
+	source, err := op.Read(ctx, migration.zone, source.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, source.InstanceStatus.IsUp())
+}
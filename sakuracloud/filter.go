@@ -15,18 +15,53 @@
 package sakuracloud
 
 import (
+	"fmt"
 	"os"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const filterAttrName = "filter"
 
+// conditionで指定可能なマッチ方法、指定された場合はAPIではなくクライアント側で評価する
+const (
+	filterOperatorExact  = "exact"
+	filterOperatorPrefix = "prefix"
+	filterOperatorRegex  = "regex"
+)
+
+var filterOperatorStrings = []string{filterOperatorExact, filterOperatorPrefix, filterOperatorRegex}
+
 type filterSchemaOption struct {
 	excludeTags bool
+	// conditionNames 共通の項目(ID/Name/Description/Tags.Name)以外に、クライアント側で評価するconditionのnameの例として記載する項目
+	conditionNames []string
+	// excludeSelector trueの場合most_recent/sort_byを除外する(複数件を返すデータソース向け)
+	excludeSelector bool
+}
+
+// filterCommonConditionNames 全てのデータソースでクライアント側で評価するconditionのnameとして指定可能な項目
+//
+// nameはAPIの検索キーであり、クライアント側で評価する場合は同名のフィールド(`.`区切りでネストしたフィールド)を参照する
+var filterCommonConditionNames = []string{"ID", "Name", "Description"}
+
+const filterTagsConditionName = "Tags.Name"
+
+// filterConditionNames クライアント側で評価するconditionのnameの例として記載する項目を返す
+func filterConditionNames(opt *filterSchemaOption) []string {
+	names := append([]string{}, filterCommonConditionNames...)
+	if !opt.excludeTags {
+		names = append(names, filterTagsConditionName)
+	}
+	return append(names, opt.conditionNames...)
 }
 
 var (
@@ -46,6 +81,7 @@ func filterSchema(opt *filterSchemaOption) *schema.Schema {
 	if opt.excludeTags {
 		keys = filterConfigKeys
 	}
+	conditionNames := filterConditionNames(opt)
 	s := map[string]*schema.Schema{
 		"id": {
 			Type:         schema.TypeString,
@@ -75,25 +111,64 @@ func filterSchema(opt *filterSchemaOption) *schema.Schema {
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"name": {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "The name of the target field. This value is case-sensitive",
+						Type:     schema.TypeString,
+						Required: true,
+						Description: descf(
+							"The name of the target field. This value is case-sensitive. For the keys evaluated by the API, check out finding section in the [SakuraCloud API reference](https://developer.sakura.ad.jp/cloud/api/1.1/). If the condition is evaluated on the client side, this must be a field of the resource such as [%s]",
+							conditionNames,
+						),
 					},
 
 					"values": {
 						Type:        schema.TypeList,
 						Required:    true,
 						Elem:        &schema.Schema{Type: schema.TypeString},
-						Description: "The values of the condition. If multiple values ​​are specified, they combined as AND condition",
+						Description: "The values of the condition. If multiple values ​​are specified, they combined as AND condition, or as OR condition when `any` is true. For a list field such as `Tags.Name`, each value matches if any element of the field matches",
+					},
+					"any": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "The flag to combine the `values` as OR condition. If this is true, the condition is evaluated on the client side with `operator`(default: `exact`)",
+					},
+					"operator": {
+						Type:             schema.TypeString,
+						Optional:         true,
+						ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(filterOperatorStrings, false)),
+						Description: descf(
+							"The operator used to match the values. This must be one of [%s]. If this is specified, the condition is evaluated on the client side",
+							filterOperatorStrings,
+						),
+					},
+					"not": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "The flag to negate the whole condition. If this is true, the condition is evaluated on the client side with `operator`(default: `exact`)",
 					},
 				},
 			},
-			Description: "One or more name/values pairs used for filtering. There are several valid keys, for a full reference, check out finding section in the [SakuraCloud API reference](https://developer.sakura.ad.jp/cloud/api/1.1/)",
+			Description: "One or more name/values pairs used for filtering. If multiple conditions are specified, they combined as AND condition",
 		},
 	}
 	if opt.excludeTags {
 		delete(s, "tags")
 	}
+	if !opt.excludeSelector {
+		s["most_recent"] = &schema.Schema{
+			Type:          schema.TypeBool,
+			Optional:      true,
+			ConflictsWith: []string{"filter.0.sort_by"},
+			Description:   "The flag to use the most recently created resource when multiple resources match",
+		}
+		s["sort_by"] = &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pluralSortByStrings, false)),
+			Description: descf(
+				"The key to sort the resources in ascending order when multiple resources match. The first one is used. This must be one of [%s]",
+				pluralSortByStrings,
+			),
+		}
+	}
 	return &schema.Schema{
 		Type:     schema.TypeList,
 		Optional: true,
@@ -149,3 +224,240 @@ func hasTags(target interface{}, cond []string) bool {
 	}
 	return true
 }
+
+type filterCondition struct {
+	name     string
+	values   []string
+	operator string
+	not      bool
+	anyOf    bool
+	regexps  []*regexp.Regexp
+}
+
+// isClientSideFilterCondition conditionをAPIではなくクライアント側で評価すべきか
+func isClientSideFilterCondition(condition map[string]interface{}) bool {
+	operator, _ := condition["operator"].(string)
+	not, _ := condition["not"].(bool)
+	anyOf, _ := condition["any"].(bool)
+	return operator != "" || not || anyOf
+}
+
+func expandClientSideFilterConditions(d resourceValueGettable) ([]*filterCondition, error) {
+	rawFilters, ok := d.GetOk(filterAttrName)
+	if !ok {
+		return nil, nil
+	}
+	filters, ok := rawFilters.([]interface{})
+	if !ok || len(filters) == 0 || filters[0] == nil {
+		return nil, nil
+	}
+	rawConditions, ok := filters[0].(map[string]interface{})["condition"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var conditions []*filterCondition
+	for _, rawCondition := range rawConditions {
+		mv := rawCondition.(map[string]interface{})
+		if !isClientSideFilterCondition(mv) {
+			continue
+		}
+		condition := &filterCondition{
+			name:     mv["name"].(string),
+			values:   expandStringList(mv["values"].([]interface{})),
+			operator: forceString(mv["operator"]),
+			not:      forceBool(mv["not"]),
+			anyOf:    forceBool(mv["any"]),
+		}
+		if condition.operator == "" {
+			condition.operator = filterOperatorExact
+		}
+		if condition.operator == filterOperatorRegex {
+			for _, v := range condition.values {
+				r, err := regexp.Compile(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid regular expression in filter condition %q: %s", condition.name, err)
+				}
+				condition.regexps = append(condition.regexps, r)
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// match valuesの全て(anyの場合はいずれか)が対象のフィールド値のいずれかにマッチするか(notの場合は反転)
+//
+// APIで評価する場合と同じく、anyが指定されていない場合はvaluesをAND条件として扱う
+func (c *filterCondition) match(target interface{}) bool {
+	fieldValues := filterFieldValues(reflect.ValueOf(target), strings.Split(c.name, "."))
+	matched := len(fieldValues) > 0 && !c.anyOf
+	for i, v := range c.values {
+		if len(fieldValues) == 0 {
+			break
+		}
+		if c.matchAny(fieldValues, i, v) == c.anyOf {
+			matched = c.anyOf
+			break
+		}
+	}
+	return matched != c.not
+}
+
+func (c *filterCondition) matchAny(fieldValues []string, i int, v string) bool {
+	for _, fieldValue := range fieldValues {
+		switch c.operator {
+		case filterOperatorPrefix:
+			if strings.HasPrefix(fieldValue, v) {
+				return true
+			}
+		case filterOperatorRegex:
+			if c.regexps[i].MatchString(fieldValue) {
+				return true
+			}
+		default:
+			if fieldValue == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterFieldValues フィールド名(`.`区切りでネストしたフィールドを指定可能)から値を文字列として取得する
+//
+// スライスの場合は各要素の値を返す。また、文字列のスライスに対するフィールド指定(Tags.Nameなど)は無視する
+func filterFieldValues(v reflect.Value, path []string) []string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		var values []string
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, filterFieldValues(v.Index(i), path)...)
+		}
+		return values
+	case reflect.Struct:
+		if len(path) == 0 {
+			break
+		}
+		f := v.FieldByName(path[0])
+		if !f.IsValid() {
+			return nil
+		}
+		return filterFieldValues(f, path[1:])
+	}
+
+	if !v.CanInterface() {
+		return nil
+	}
+	return []string{fmt.Sprint(v.Interface())}
+}
+
+// filterFieldExists フィールド名(`.`区切りでネストしたフィールドを指定可能)がクライアント側で評価可能かを型から判定する
+//
+// filterFieldValuesと同じく、スライスは要素の型を、文字列のスライスに対するフィールド指定(Tags.Nameなど)は要素自体を参照する
+func filterFieldExists(t reflect.Type, path []string) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if len(path) == 0 || t.Kind() != reflect.Struct {
+		return true
+	}
+	f, ok := t.FieldByName(path[0])
+	if !ok || f.PkgPath != "" {
+		return false
+	}
+	return filterFieldExists(f.Type, path[1:])
+}
+
+func filterCreatedAt(target interface{}) time.Time {
+	v := reflect.Indirect(reflect.ValueOf(target))
+	if v.Kind() != reflect.Struct {
+		return time.Time{}
+	}
+	if f := v.FieldByName("CreatedAt"); f.IsValid() {
+		if t, ok := f.Interface().(time.Time); ok {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func filterName(target interface{}) string {
+	if t, ok := target.(nameFilterable); ok {
+		return t.GetName()
+	}
+	return ""
+}
+
+// filterTargets クライアント側で評価するconditionで検索結果を絞り込む
+func filterTargets(d resourceValueGettable, targets []interface{}) ([]interface{}, error) {
+	conditions, err := expandClientSideFilterConditions(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(conditions) == 0 || len(targets) == 0 {
+		return targets, nil
+	}
+	for _, c := range conditions {
+		if !filterFieldExists(reflect.TypeOf(targets[0]), strings.Split(c.name, ".")) {
+			return nil, fmt.Errorf("filter condition %q can't be evaluated on the client side: the field is not found", c.name)
+		}
+	}
+
+	var results []interface{}
+	for _, t := range targets {
+		matched := true
+		for _, c := range conditions {
+			if !c.match(t) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			results = append(results, t)
+		}
+	}
+	return results, nil
+}
+
+// selectFilteredTarget 検索結果(スライス)からフィルタ条件とmost_recent/sort_byに従い1件を選択する
+//
+// 該当するものがない場合はnilを返す
+func selectFilteredTarget(d resourceValueGettable, targets interface{}) (interface{}, diag.Diagnostics) {
+	rv := reflect.ValueOf(targets)
+	var values []interface{}
+	for i := 0; i < rv.Len(); i++ {
+		values = append(values, rv.Index(i).Interface())
+	}
+
+	values, err := filterTargets(d, values)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if len(values) == 0 {
+		return nil, filterNoResultErr()
+	}
+
+	switch {
+	case boolOrDefault(d, "filter.0.most_recent"):
+		sort.SliceStable(values, func(i, j int) bool {
+			return filterCreatedAt(values[i]).After(filterCreatedAt(values[j]))
+		})
+	case stringOrDefault(d, "filter.0.sort_by") == pluralSortByName:
+		sort.SliceStable(values, func(i, j int) bool {
+			return filterName(values[i]) < filterName(values[j])
+		})
+	case stringOrDefault(d, "filter.0.sort_by") == pluralSortByCreatedAt:
+		sort.SliceStable(values, func(i, j int) bool {
+			return filterCreatedAt(values[i]).Before(filterCreatedAt(values[j]))
+		})
+	}
+	return values[0], nil
+}
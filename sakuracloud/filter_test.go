@@ -16,7 +16,12 @@ package sakuracloud
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -122,3 +127,216 @@ func TestHasTagsFilter(t *testing.T) {
 		assert.Equal(t, e.hit, hasTags(target, e.conditions))
 	}
 }
+
+func testFilterResourceData(conditions []interface{}, selector map[string]interface{}) resourceValueGettable {
+	filter := map[string]interface{}{"condition": conditions}
+	for k, v := range selector {
+		filter[k] = v
+	}
+	values := map[string]interface{}{
+		filterAttrName: []interface{}{filter},
+	}
+	for k, v := range selector {
+		values["filter.0."+k] = v
+	}
+	return mapToResourceData(values)
+}
+
+func TestFilterTargets(t *testing.T) {
+	now := time.Now()
+	archives := []*sacloud.Archive{
+		{ID: 1, Name: "app-v1", Tags: types.Tags{"A"}, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: 2, Name: "app-v2", Tags: types.Tags{"B", "C"}, CreatedAt: now.Add(-time.Hour)},
+		{ID: 3, Name: "app-latest", Tags: types.Tags{"B"}, CreatedAt: now},
+		{ID: 4, Name: "other-v3", Tags: types.Tags{"D"}, CreatedAt: now.Add(-3 * time.Hour)},
+	}
+
+	expects := []struct {
+		msg        string
+		conditions []interface{}
+		expect     []int64
+	}{
+		{
+			msg: "exact",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{"app-v1"}, "operator": "exact"},
+			},
+			expect: []int64{1},
+		},
+		{
+			msg: "exact values are combined as AND condition",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{"app-v1", "app-v2"}, "operator": "exact"},
+			},
+			expect: nil,
+		},
+		{
+			msg: "prefix",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{"app-"}, "operator": "prefix"},
+			},
+			expect: []int64{1, 2, 3},
+		},
+		{
+			msg: "regex",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{`^app-v\d+$`}, "operator": "regex"},
+			},
+			expect: []int64{1, 2},
+		},
+		{
+			msg: "regex values are combined as OR condition with any",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{`^app-v1$`, `^other-`}, "operator": "regex", "any": true},
+			},
+			expect: []int64{1, 4},
+		},
+		{
+			msg: "tagged B and C",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Tags.Name", "values": []interface{}{"B", "C"}, "operator": "exact"},
+			},
+			expect: []int64{2},
+		},
+		{
+			msg: "tagged B but not C",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Tags.Name", "values": []interface{}{"B"}, "operator": "exact"},
+				map[string]interface{}{"name": "Tags.Name", "values": []interface{}{"C"}, "not": true},
+			},
+			expect: []int64{3},
+		},
+		{
+			msg: "not tagged both B and C",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Tags.Name", "values": []interface{}{"B", "C"}, "not": true},
+			},
+			expect: []int64{1, 3, 4},
+		},
+		{
+			msg: "exact values are combined as OR condition with any",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{"app-v1", "app-v2"}, "any": true},
+			},
+			expect: []int64{1, 2},
+		},
+		{
+			msg: "prefix values are combined as OR condition with any",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{"other-", "app-l"}, "operator": "prefix", "any": true},
+			},
+			expect: []int64{3, 4},
+		},
+		{
+			msg: "tagged A or B but not C",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Tags.Name", "values": []interface{}{"A", "B"}, "any": true},
+				map[string]interface{}{"name": "Tags.Name", "values": []interface{}{"C"}, "not": true},
+			},
+			expect: []int64{1, 3},
+		},
+		{
+			msg: "tagged neither A nor B",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Tags.Name", "values": []interface{}{"A", "B"}, "any": true, "not": true},
+			},
+			expect: []int64{4},
+		},
+		{
+			msg: "conditions evaluated by API are ignored",
+			conditions: []interface{}{
+				map[string]interface{}{"name": "Name", "values": []interface{}{"foobar"}},
+			},
+			expect: []int64{1, 2, 3, 4},
+		},
+	}
+
+	for _, e := range expects {
+		var targets []interface{}
+		for _, a := range archives {
+			targets = append(targets, a)
+		}
+		results, err := filterTargets(testFilterResourceData(e.conditions, nil), targets)
+		if !assert.NoError(t, err, e.msg) {
+			continue
+		}
+		var ids []int64
+		for _, r := range results {
+			ids = append(ids, r.(*sacloud.Archive).ID.Int64())
+		}
+		assert.Equal(t, e.expect, ids, e.msg)
+	}
+
+	_, err := filterTargets(testFilterResourceData([]interface{}{
+		map[string]interface{}{"name": "Name", "values": []interface{}{"("}, "operator": "regex"},
+	}, nil), nil)
+	assert.Error(t, err)
+}
+
+func TestFilterSchema_conditionNames(t *testing.T) {
+	// APIで評価するconditionのnameは任意の検索キーを指定できる
+	for _, name := range []string{"Name", "Tags.Name", "Scope", "SettingsHash", "Interfaces.Switch.ID"} {
+		r := &schema.Resource{Schema: map[string]*schema.Schema{filterAttrName: filterSchema(&filterSchemaOption{excludeTags: true})}}
+		diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			filterAttrName: []interface{}{
+				map[string]interface{}{
+					"condition": []interface{}{
+						map[string]interface{}{"name": name, "values": []interface{}{"foobar"}},
+					},
+				},
+			},
+		}))
+		assert.False(t, diags.HasError(), name)
+	}
+}
+
+func TestFilterTargets_clientSideConditionNames(t *testing.T) {
+	archives := []interface{}{
+		&sacloud.Archive{ID: 1, Name: "foo", Tags: types.Tags{"tag1"}},
+	}
+
+	expects := []struct {
+		name   string
+		hasErr bool
+	}{
+		{name: "Name", hasErr: false},
+		{name: "Tags.Name", hasErr: false},
+		{name: "Scope", hasErr: false},
+		{name: "SizeMB", hasErr: false},
+		{name: "name", hasErr: true},
+		{name: "Foobar", hasErr: true},
+	}
+	for _, e := range expects {
+		_, err := filterTargets(testFilterResourceData([]interface{}{
+			map[string]interface{}{"name": e.name, "values": []interface{}{"foo"}, "operator": "prefix"},
+		}, nil), archives)
+		assert.Equal(t, e.hasErr, err != nil, e.name)
+	}
+}
+
+func TestSelectFilteredTarget(t *testing.T) {
+	now := time.Now()
+	archives := []*sacloud.Archive{
+		{ID: 1, Name: "app-v2", CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, Name: "app-v3", CreatedAt: now},
+		{ID: 3, Name: "app-v1", CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	conditions := []interface{}{
+		map[string]interface{}{"name": "Name", "values": []interface{}{`^app-v\d+$`}, "operator": "regex"},
+	}
+
+	expects := []struct {
+		selector map[string]interface{}
+		expect   int64
+	}{
+		{selector: nil, expect: 1},
+		{selector: map[string]interface{}{"most_recent": true}, expect: 2},
+		{selector: map[string]interface{}{"sort_by": "name"}, expect: 3},
+		{selector: map[string]interface{}{"sort_by": "created_at"}, expect: 3},
+	}
+	for _, e := range expects {
+		target, diags := selectFilteredTarget(testFilterResourceData(conditions, e.selector), archives)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, e.expect, target.(*sacloud.Archive).ID.Int64(), e.selector)
+	}
+}
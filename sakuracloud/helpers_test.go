@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/helper/api"
 )
 
 func skipIfFakeModeEnabled(t *testing.T) {
@@ -33,6 +34,16 @@ func skipIfFakeModeEnabled(t *testing.T) {
 	}
 }
 
+// setupFakeDefaultsForUnitTest fakeドライバを用いる単体テスト向けにポーリング間隔などを短くする
+//
+// 実APIを用いるアクセプタンステストの実行中はデフォルト値を変更しないようにスキップする
+func setupFakeDefaultsForUnitTest(t *testing.T) {
+	if os.Getenv(resource.TestEnvVar) != "" && !isFakeModeEnabled() {
+		t.Skip("This test only run if TF_ACC environment variable is not set or FAKE_MODE environment variable is set")
+	}
+	api.SetupFakeDefaults()
+}
+
 func isFakeModeEnabled() bool {
 	fakeMode := os.Getenv("FAKE_MODE")
 	return fakeMode != ""
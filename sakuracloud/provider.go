@@ -67,6 +67,12 @@ func Provider() *schema.Provider {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "A list of available SakuraCloud zone name. It can also be sourced via a shared credentials file if `profile` is specified. Default:[`is1a`, `is1b`, `tk1a`, `tk1v`]",
 			},
+			"multi_zone": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SAKURACLOUD_MULTI_ZONE", false),
+				Description: "The flag to enable multi-zone mode. If this is true, `zone` and `zones` are validated against the SakuraCloud API, and the data sources which return multiple resources search all of `zones` when `zone` isn't specified. It can also be sourced from the `SAKURACLOUD_MULTI_ZONE` environment variables",
+			},
 			"default_zone": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -129,6 +135,50 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SAKURACLOUD_TRACE", "SAKURACLOUD_TRACE_MODE"}, ""),
 				Description: "The flag to enable output trace log. It can also be sourced from the `SAKURACLOUD_TRACE` environment variables, or via a shared credentials file if `profile` is specified",
 			},
+			"trace_format": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("SAKURACLOUD_TRACE_FORMAT", traceFormatText),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(traceFormats, false)),
+				Description: descf(
+					"The format of trace log. This must be one of [%s]. It can also be sourced from the `SAKURACLOUD_TRACE_FORMAT` environment variables. Default:`%s`",
+					traceFormats, traceFormatText,
+				),
+			},
+			"trace_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SAKURACLOUD_TRACE_FILE_PATH", ""),
+				Description: "The file path to write trace log. If this isn't specified, trace log is written to the log of Terraform. It can also be sourced from the `SAKURACLOUD_TRACE_FILE_PATH` environment variables",
+			},
+			"otel_exporter": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("SAKURACLOUD_OTEL_EXPORTER", ""),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(otelExporters, false)),
+				Description: descf(
+					"The exporter of OpenTelemetry tracing. This must be one of [%s]. If this isn't specified, tracing is disabled. It can also be sourced from the `SAKURACLOUD_OTEL_EXPORTER` environment variables",
+					otelExporters,
+				),
+			},
+			"otel_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SAKURACLOUD_OTEL_ENDPOINT", ""),
+				Description: "The address of OTLP/HTTP receiver in the form of `<host>:<port>`(e.g. `localhost:4318`) used when `otel_exporter` is `otlp`. The spans are sent to `/v1/traces` as JSON. A full URL with a scheme or a path isn't supported. It can also be sourced from the `SAKURACLOUD_OTEL_ENDPOINT` environment variables. Default:`localhost:4318`",
+			},
+			"otel_insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SAKURACLOUD_OTEL_INSECURE", false),
+				Description: "The flag to send spans over HTTP instead of HTTPS for the OTLP exporter. The scheme of the receiver is determined only by this flag. It can also be sourced from the `SAKURACLOUD_OTEL_INSECURE` environment variables",
+			},
+			"otel_file_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SAKURACLOUD_OTEL_FILE_PATH", ""),
+				Description: "The file path to write spans as JSON lines used when `otel_exporter` is `file`. It can also be sourced from the `SAKURACLOUD_OTEL_FILE_PATH` environment variables",
+			},
 			"fake_mode": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -141,72 +191,131 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("FAKE_STORE_PATH", ""),
 				Description: "The file path used by SakuraCloud API fake driver for storing fake data. It is for debugging or developping the provider. It can also be sourced from the `FAKE_STORE_PATH` environment variables, or via a shared credentials file if `profile` is specified",
 			},
+			"default_tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Any tags to assign to all resources which have the `tags` attribute. The tags are merged with the `tags` of each resource and the result is exported as `tags_all`",
+			},
+			"ignore_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "A list of tag keys to ignore. The tag which equals to the key or starts with `<key>=` is ignored",
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "A list of tag prefixes to ignore",
+						},
+					},
+				},
+				Description: "The settings of tags which are managed outside of Terraform. The ignored tags are kept on the resources and are not shown as differences",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"sakuracloud_archive":            dataSourceSakuraCloudArchive(),
-			"sakuracloud_bridge":             dataSourceSakuraCloudBridge(),
-			"sakuracloud_cdrom":              dataSourceSakuraCloudCDROM(),
-			"sakuracloud_container_registry": dataSourceSakuraCloudContainerRegistry(),
-			"sakuracloud_database":           dataSourceSakuraCloudDatabase(),
-			"sakuracloud_disk":               dataSourceSakuraCloudDisk(),
-			"sakuracloud_dns":                dataSourceSakuraCloudDNS(),
-			"sakuracloud_esme":               dataSourceSakuraCloudESME(),
-			"sakuracloud_gslb":               dataSourceSakuraCloudGSLB(),
-			"sakuracloud_icon":               dataSourceSakuraCloudIcon(),
-			"sakuracloud_internet":           dataSourceSakuraCloudInternet(),
-			"sakuracloud_load_balancer":      dataSourceSakuraCloudLoadBalancer(),
-			"sakuracloud_local_router":       dataSourceSakuraCloudLocalRouter(),
-			"sakuracloud_note":               dataSourceSakuraCloudNote(),
-			"sakuracloud_nfs":                dataSourceSakuraCloudNFS(),
-			"sakuracloud_packet_filter":      dataSourceSakuraCloudPacketFilter(),
-			"sakuracloud_proxylb":            dataSourceSakuraCloudProxyLB(),
-			"sakuracloud_private_host":       dataSourceSakuraCloudPrivateHost(),
-			"sakuracloud_simple_monitor":     dataSourceSakuraCloudSimpleMonitor(),
-			"sakuracloud_server":             dataSourceSakuraCloudServer(),
-			"sakuracloud_server_vnc_info":    dataSourceSakuraCloudServerVNCInfo(),
-			"sakuracloud_ssh_key":            dataSourceSakuraCloudSSHKey(),
-			"sakuracloud_subnet":             dataSourceSakuraCloudSubnet(),
-			"sakuracloud_switch":             dataSourceSakuraCloudSwitch(),
-			"sakuracloud_vpc_router":         dataSourceSakuraCloudVPCRouter(),
-			"sakuracloud_webaccel":           dataSourceSakuraCloudWebAccel(),
-			"sakuracloud_zone":               dataSourceSakuraCloudZone(),
+			"sakuracloud_archive":                 dataSourceSakuraCloudArchive(),
+			"sakuracloud_archives":                dataSourceSakuraCloudArchives(),
+			"sakuracloud_bridge":                  dataSourceSakuraCloudBridge(),
+			"sakuracloud_cdrom":                   dataSourceSakuraCloudCDROM(),
+			"sakuracloud_container_registry":      dataSourceSakuraCloudContainerRegistry(),
+			"sakuracloud_cost_estimate":           dataSourceSakuraCloudCostEstimate(),
+			"sakuracloud_database":                dataSourceSakuraCloudDatabase(),
+			"sakuracloud_databases":               dataSourceSakuraCloudDatabases(),
+			"sakuracloud_database_backup_history": dataSourceSakuraCloudDatabaseBackupHistory(),
+			"sakuracloud_database_monitor":        dataSourceSakuraCloudDatabaseMonitor(),
+			"sakuracloud_disk":                    dataSourceSakuraCloudDisk(),
+			"sakuracloud_disks":                   dataSourceSakuraCloudDisks(),
+			"sakuracloud_disk_plans":              dataSourceSakuraCloudDiskPlans(),
+			"sakuracloud_dns":                     dataSourceSakuraCloudDNS(),
+			"sakuracloud_esme":                    dataSourceSakuraCloudESME(),
+			"sakuracloud_gslb":                    dataSourceSakuraCloudGSLB(),
+			"sakuracloud_icon":                    dataSourceSakuraCloudIcon(),
+			"sakuracloud_internet":                dataSourceSakuraCloudInternet(),
+			"sakuracloud_internets":               dataSourceSakuraCloudInternets(),
+			"sakuracloud_internet_plans":          dataSourceSakuraCloudInternetPlans(),
+			"sakuracloud_internet_monitor":        dataSourceSakuraCloudInternetMonitor(),
+			"sakuracloud_load_balancer":           dataSourceSakuraCloudLoadBalancer(),
+			"sakuracloud_local_router":            dataSourceSakuraCloudLocalRouter(),
+			"sakuracloud_local_router_monitor":    dataSourceSakuraCloudLocalRouterMonitor(),
+			"sakuracloud_note":                    dataSourceSakuraCloudNote(),
+			"sakuracloud_nfs":                     dataSourceSakuraCloudNFS(),
+			"sakuracloud_nfs_monitor":             dataSourceSakuraCloudNFSMonitor(),
+			"sakuracloud_packet_filter":           dataSourceSakuraCloudPacketFilter(),
+			"sakuracloud_packet_filter_evaluate":  dataSourceSakuraCloudPacketFilterEvaluate(),
+			"sakuracloud_proxylb":                 dataSourceSakuraCloudProxyLB(),
+			"sakuracloud_private_host":            dataSourceSakuraCloudPrivateHost(),
+			"sakuracloud_private_host_plans":      dataSourceSakuraCloudPrivateHostPlans(),
+			"sakuracloud_simple_monitor":          dataSourceSakuraCloudSimpleMonitor(),
+			"sakuracloud_server":                  dataSourceSakuraCloudServer(),
+			"sakuracloud_servers":                 dataSourceSakuraCloudServers(),
+			"sakuracloud_server_plans":            dataSourceSakuraCloudServerPlans(),
+			"sakuracloud_server_vnc_info":         dataSourceSakuraCloudServerVNCInfo(),
+			"sakuracloud_service_class":           dataSourceSakuraCloudServiceClass(),
+			"sakuracloud_ssh_key":                 dataSourceSakuraCloudSSHKey(),
+			"sakuracloud_subnet":                  dataSourceSakuraCloudSubnet(),
+			"sakuracloud_switch":                  dataSourceSakuraCloudSwitch(),
+			"sakuracloud_switch_topology":         dataSourceSakuraCloudSwitchTopology(),
+			"sakuracloud_switches":                dataSourceSakuraCloudSwitches(),
+			"sakuracloud_vpc_router":              dataSourceSakuraCloudVPCRouter(),
+			"sakuracloud_webaccel":                dataSourceSakuraCloudWebAccel(),
+			"sakuracloud_zone":                    dataSourceSakuraCloudZone(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"sakuracloud_auto_backup":           resourceSakuraCloudAutoBackup(),
-			"sakuracloud_archive":               resourceSakuraCloudArchive(),
-			"sakuracloud_archive_share":         resourceSakuraCloudArchiveShare(),
-			"sakuracloud_bridge":                resourceSakuraCloudBridge(),
-			"sakuracloud_cdrom":                 resourceSakuraCloudCDROM(),
-			"sakuracloud_container_registry":    resourceSakuraCloudContainerRegistry(),
-			"sakuracloud_database":              resourceSakuraCloudDatabase(),
-			"sakuracloud_database_read_replica": resourceSakuraCloudDatabaseReadReplica(),
-			"sakuracloud_disk":                  resourceSakuraCloudDisk(),
-			"sakuracloud_dns":                   resourceSakuraCloudDNS(),
-			"sakuracloud_dns_record":            resourceSakuraCloudDNSRecord(),
-			"sakuracloud_esme":                  resourceSakuraCloudESME(),
-			"sakuracloud_gslb":                  resourceSakuraCloudGSLB(),
-			"sakuracloud_icon":                  resourceSakuraCloudIcon(),
-			"sakuracloud_internet":              resourceSakuraCloudInternet(),
-			"sakuracloud_ipv4_ptr":              resourceSakuraCloudIPv4Ptr(),
-			"sakuracloud_load_balancer":         resourceSakuraCloudLoadBalancer(),
-			"sakuracloud_local_router":          resourceSakuraCloudLocalRouter(),
-			"sakuracloud_mobile_gateway":        resourceSakuraCloudMobileGateway(),
-			"sakuracloud_note":                  resourceSakuraCloudNote(),
-			"sakuracloud_nfs":                   resourceSakuraCloudNFS(),
-			"sakuracloud_packet_filter":         resourceSakuraCloudPacketFilter(),
-			"sakuracloud_packet_filter_rules":   resourceSakuraCloudPacketFilterRules(),
-			"sakuracloud_proxylb":               resourceSakuraCloudProxyLB(),
-			"sakuracloud_proxylb_acme":          resourceSakuraCloudProxyLBACME(),
-			"sakuracloud_private_host":          resourceSakuraCloudPrivateHost(),
-			"sakuracloud_sim":                   resourceSakuraCloudSIM(),
-			"sakuracloud_simple_monitor":        resourceSakuraCloudSimpleMonitor(),
-			"sakuracloud_server":                resourceSakuraCloudServer(),
-			"sakuracloud_ssh_key":               resourceSakuraCloudSSHKey(),
-			"sakuracloud_ssh_key_gen":           resourceSakuraCloudSSHKeyGen(),
-			"sakuracloud_subnet":                resourceSakuraCloudSubnet(),
-			"sakuracloud_switch":                resourceSakuraCloudSwitch(),
-			"sakuracloud_vpc_router":            resourceSakuraCloudVPCRouter(),
-			"sakuracloud_webaccel_certificate":  resourceSakuraCloudWebAccelCertificate(),
+			"sakuracloud_auto_backup":              resourceSakuraCloudAutoBackup(),
+			"sakuracloud_archive":                  resourceSakuraCloudArchive(),
+			"sakuracloud_archive_share":            resourceSakuraCloudArchiveShare(),
+			"sakuracloud_bridge":                   resourceSakuraCloudBridge(),
+			"sakuracloud_cdrom":                    resourceSakuraCloudCDROM(),
+			"sakuracloud_container_registry":       resourceSakuraCloudContainerRegistry(),
+			"sakuracloud_database":                 resourceSakuraCloudDatabase(),
+			"sakuracloud_database_backup":          resourceSakuraCloudDatabaseBackup(),
+			"sakuracloud_database_read_replica":    resourceSakuraCloudDatabaseReadReplica(),
+			"sakuracloud_database_restore":         resourceSakuraCloudDatabaseRestore(),
+			"sakuracloud_disk":                     resourceSakuraCloudDisk(),
+			"sakuracloud_disk_edit":                resourceSakuraCloudDiskEdit(),
+			"sakuracloud_dns":                      resourceSakuraCloudDNS(),
+			"sakuracloud_dns_record":               resourceSakuraCloudDNSRecord(),
+			"sakuracloud_esme":                     resourceSakuraCloudESME(),
+			"sakuracloud_gslb":                     resourceSakuraCloudGSLB(),
+			"sakuracloud_icon":                     resourceSakuraCloudIcon(),
+			"sakuracloud_internet":                 resourceSakuraCloudInternet(),
+			"sakuracloud_internet_ptr_records":     resourceSakuraCloudInternetPtrRecords(),
+			"sakuracloud_ip_address_allocation":    resourceSakuraCloudIPAddressAllocation(),
+			"sakuracloud_ipv4_ptr":                 resourceSakuraCloudIPv4Ptr(),
+			"sakuracloud_load_balancer":            resourceSakuraCloudLoadBalancer(),
+			"sakuracloud_local_router":             resourceSakuraCloudLocalRouter(),
+			"sakuracloud_local_router_peer":        resourceSakuraCloudLocalRouterPeer(),
+			"sakuracloud_mobile_gateway":           resourceSakuraCloudMobileGateway(),
+			"sakuracloud_note":                     resourceSakuraCloudNote(),
+			"sakuracloud_nfs":                      resourceSakuraCloudNFS(),
+			"sakuracloud_packet_filter":            resourceSakuraCloudPacketFilter(),
+			"sakuracloud_packet_filter_attachment": resourceSakuraCloudPacketFilterAttachment(),
+			"sakuracloud_packet_filter_rules":      resourceSakuraCloudPacketFilterRules(),
+			"sakuracloud_proxylb":                  resourceSakuraCloudProxyLB(),
+			"sakuracloud_proxylb_acme":             resourceSakuraCloudProxyLBACME(),
+			"sakuracloud_private_host":             resourceSakuraCloudPrivateHost(),
+			"sakuracloud_sim":                      resourceSakuraCloudSIM(),
+			"sakuracloud_simple_monitor":           resourceSakuraCloudSimpleMonitor(),
+			"sakuracloud_server":                   resourceSakuraCloudServer(),
+			"sakuracloud_server_network_interface": resourceSakuraCloudServerNetworkInterface(),
+			"sakuracloud_ssh_key":                  resourceSakuraCloudSSHKey(),
+			"sakuracloud_ssh_key_gen":              resourceSakuraCloudSSHKeyGen(),
+			"sakuracloud_subnet":                   resourceSakuraCloudSubnet(),
+			"sakuracloud_switch":                   resourceSakuraCloudSwitch(),
+			"sakuracloud_switch_bridge_connection": resourceSakuraCloudSwitchBridgeConnection(),
+			"sakuracloud_vpc_router":               resourceSakuraCloudVPCRouter(),
+			"sakuracloud_webaccel_certificate":     resourceSakuraCloudWebAccelCertificate(),
 		},
 	}
 
@@ -217,30 +326,44 @@ func Provider() *schema.Provider {
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(d, terraformVersion)
+		return providerConfigure(d, terraformVersion, collectSensitiveKeys(provider.ResourcesMap, provider.DataSourcesMap))
 	}
 
+	traceResources(provider.DataSourcesMap, true)
+	traceResources(provider.ResourcesMap, false)
+
 	return provider
 }
 
-func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+func providerConfigure(d *schema.ResourceData, terraformVersion string, sensitiveKeys []string) (interface{}, diag.Diagnostics) {
 	config := Config{
 		Profile:             d.Get("profile").(string),
 		AccessToken:         d.Get("token").(string),
 		AccessTokenSecret:   d.Get("secret").(string),
 		Zone:                d.Get("zone").(string),
 		Zones:               expandStringList(d.Get("zones").([]interface{})),
+		MultiZone:           d.Get("multi_zone").(bool),
 		DefaultZone:         d.Get("default_zone").(string),
 		TraceMode:           d.Get("trace").(string),
+		TraceFormat:         d.Get("trace_format").(string),
+		TraceFilePath:       d.Get("trace_file_path").(string),
 		APIRootURL:          d.Get("api_root_url").(string),
 		RetryMax:            d.Get("retry_max").(int),
 		RetryWaitMax:        d.Get("retry_wait_max").(int),
 		RetryWaitMin:        d.Get("retry_wait_min").(int),
 		APIRequestTimeout:   d.Get("api_request_timeout").(int),
 		APIRequestRateLimit: d.Get("api_request_rate_limit").(int),
+		OTelExporter:        d.Get("otel_exporter").(string),
+		OTelEndpoint:        d.Get("otel_endpoint").(string),
+		OTelInsecure:        d.Get("otel_insecure").(bool),
+		OTelFilePath:        d.Get("otel_file_path").(string),
 		FakeMode:            d.Get("fake_mode").(string),
 		FakeStorePath:       d.Get("fake_store_path").(string),
+		DefaultTags:         expandStringList(d.Get("default_tags").(*schema.Set).List()),
+		IgnoreTagKeys:       expandStringList(d.Get("ignore_tags.0.keys").(*schema.Set).List()),
+		IgnoreTagPrefixes:   expandStringList(d.Get("ignore_tags.0.key_prefixes").(*schema.Set).List()),
 		terraformVersion:    terraformVersion,
+		sensitiveKeys:       sensitiveKeys,
 	}
 
 	client, err := config.NewClient()
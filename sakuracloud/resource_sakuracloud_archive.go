@@ -27,18 +27,21 @@ import (
 )
 
 func resourceSakuraCloudArchive() *schema.Resource {
-	resourceName := "archive"
+	resourceName := "Archive"
 
 	return &schema.Resource{
 		CreateContext: resourceSakuraCloudArchiveCreate,
 		ReadContext:   resourceSakuraCloudArchiveRead,
 		UpdateContext: resourceSakuraCloudArchiveUpdate,
 		DeleteContext: resourceSakuraCloudArchiveDelete,
-		CustomizeDiff: customdiff.ComputedIf("hash", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
-			return d.HasChange("archive_file")
-		}),
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf("hash", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("archive_file")
+			}),
+			customizeDiffTagsAll,
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudArchiveRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -112,6 +115,7 @@ func resourceSakuraCloudArchive() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 		},
 	}
@@ -174,7 +178,7 @@ func resourceSakuraCloudArchiveUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("could not read SakuraCloud Archive[%s]: %s", d.Id(), err)
 	}
 
-	if _, err = archiveOp.Update(ctx, zone, archive.ID, expandArchiveUpdateRequest(d)); err != nil {
+	if _, err = archiveOp.Update(ctx, zone, archive.ID, expandArchiveUpdateRequest(d, client)); err != nil {
 		return diag.Errorf("updating SakuraCloud Archive[%s] is failed: %s", d.Id(), err)
 	}
 
@@ -216,5 +220,5 @@ func setArchiveResourceData(d *schema.ResourceData, client *APIClient, data *sac
 	d.Set("source_archive_id", d.Get("source_archive_id").(string)) // nolint
 	d.Set("source_disk_id", d.Get("source_disk_id").(string))       // nolint
 	d.Set("source_shared_key", d.Get("source_shared_key").(string)) // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
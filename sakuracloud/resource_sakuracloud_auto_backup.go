@@ -32,8 +32,9 @@ func resourceSakuraCloudAutoBackup() *schema.Resource {
 		ReadContext:   resourceSakuraCloudAutoBackupRead,
 		UpdateContext: resourceSakuraCloudAutoBackupUpdate,
 		DeleteContext: resourceSakuraCloudAutoBackupDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudAutoBackupRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -71,6 +72,7 @@ func resourceSakuraCloudAutoBackup() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 		},
 	}
@@ -88,7 +90,7 @@ func resourceSakuraCloudAutoBackupCreate(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	autoBackup, err := autoBackupOp.Create(ctx, zone, expandAutoBackupCreateRequest(d))
+	autoBackup, err := autoBackupOp.Create(ctx, zone, expandAutoBackupCreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud AutoBackup is failed: %s", err)
 	}
@@ -132,7 +134,7 @@ func resourceSakuraCloudAutoBackupUpdate(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	if _, err = autoBackupOp.Update(ctx, zone, autoBackup.ID, expandAutoBackupUpdateRequest(d, autoBackup)); err != nil {
+	if _, err = autoBackupOp.Update(ctx, zone, autoBackup.ID, expandAutoBackupUpdateRequest(d, client, autoBackup)); err != nil {
 		return diag.Errorf("updating SakuraCloud AutoBackup[%s] is failed: %s", d.Id(), err)
 	}
 
@@ -173,5 +175,5 @@ func setAutoBackupResourceData(d *schema.ResourceData, client *APIClient, data *
 	if err := d.Set("weekdays", flattenBackupWeekdays(data.BackupSpanWeekdays)); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -38,11 +38,14 @@ func resourceSakuraCloudCDROM() *schema.Resource {
 		UpdateContext: resourceSakuraCloudCDROMUpdate,
 		DeleteContext: resourceSakuraCloudCDROMDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudCDROMRead),
 		},
-		CustomizeDiff: customdiff.ComputedIf("hash", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
-			return d.HasChange("iso_image_file") || d.HasChange("content")
-		}),
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf("hash", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("iso_image_file") || d.HasChange("content")
+			}),
+			customizeDiffTagsAll,
+		),
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(24 * time.Hour),
@@ -90,6 +93,7 @@ func resourceSakuraCloudCDROM() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 		},
 	}
@@ -107,7 +111,7 @@ func resourceSakuraCloudCDROMCreate(ctx context.Context, d *schema.ResourceData,
 
 	cdromOp := sacloud.NewCDROMOp(client)
 
-	cdrom, ftpServer, err := cdromOp.Create(ctx, zone, expandCDROMCreateRequest(d))
+	cdrom, ftpServer, err := cdromOp.Create(ctx, zone, expandCDROMCreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud CDROM is failed: %s", err)
 	}
@@ -160,7 +164,7 @@ func resourceSakuraCloudCDROMUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("could not read SakuraCloud CDROM[%s]: %s", d.Id(), err)
 	}
 
-	cdrom, err = cdromOp.Update(ctx, zone, cdrom.ID, expandCDROMUpdateRequest(d))
+	cdrom, err = cdromOp.Update(ctx, zone, cdrom.ID, expandCDROMUpdateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud CDROM[%s] is failed: %s", d.Id(), err)
 	}
@@ -212,7 +216,7 @@ func setCDROMResourceData(ctx context.Context, d *schema.ResourceData, client *A
 	d.Set("icon_id", data.IconID.String())   // nolint
 	d.Set("description", data.Description)   // nolint
 	d.Set("zone", getZone(d, client))        // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
 
 type uploadCDROMContext struct {
@@ -32,6 +32,7 @@ func resourceSakuraCloudContainerRegistry() *schema.Resource {
 		ReadContext:   resourceSakuraCloudContainerRegistryRead,
 		UpdateContext: resourceSakuraCloudContainerRegistryUpdate,
 		DeleteContext: resourceSakuraCloudContainerRegistryDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
@@ -73,6 +74,7 @@ func resourceSakuraCloudContainerRegistry() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"user": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -206,5 +208,5 @@ func setContainerRegistryResourceData(ctx context.Context, d *schema.ResourceDat
 	if err := d.Set("user", flattenContainerRegistryUsers(d, users.Users, includePassword)); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sacloud/libsacloud/v2/helper/power"
@@ -34,8 +35,15 @@ func resourceSakuraCloudDatabase() *schema.Resource {
 		ReadContext:   resourceSakuraCloudDatabaseRead,
 		UpdateContext: resourceSakuraCloudDatabaseUpdate,
 		DeleteContext: resourceSakuraCloudDatabaseDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffTagsAll,
+			customdiff.ComputedIf("database_version", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				// データベース種別の変更時にバージョンが指定されていない場合は新しいデータベース種別のデフォルトバージョンとする
+				return d.HasChange("database_type") && !d.HasChange("database_version")
+			}),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudDatabaseRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -57,7 +65,25 @@ func resourceSakuraCloudDatabase() *schema.Resource {
 					types.RDBMSTypeStrings,
 				),
 			},
-			"plan": schemaResourcePlan(resourceName, "10g", types.DatabasePlanStrings),
+			"database_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: descf(
+					"The version of the database. If this is omitted, the default version of the `database_type` is used. Changing this migrates the %s to a new appliance cloned from the current one",
+					resourceName,
+				),
+			},
+			"plan": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "10g",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.DatabasePlanStrings, false)),
+				Description: descf(
+					"%s. Changing this migrates the %s to a new appliance cloned from the current one with the same network settings",
+					descResourcePlan(resourceName, types.DatabasePlanStrings), resourceName,
+				),
+			},
 			"username": {
 				Type:             schema.TypeString,
 				ForceNew:         true,
@@ -173,6 +199,7 @@ func resourceSakuraCloudDatabase() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 		},
 	}
@@ -236,6 +263,15 @@ func resourceSakuraCloudDatabaseUpdate(ctx context.Context, d *schema.ResourceDa
 		return diag.Errorf("could not read SakuraCloud Database[%s]: %s", d.Id(), err)
 	}
 
+	if d.HasChanges("plan", "database_version") {
+		migrated, diags := newDatabaseMigration(client, zone).Migrate(ctx, d, db)
+		if migrated == nil {
+			return diags
+		}
+		d.SetId(migrated.ID.String()) // 移行後はIDが変更になるため(移行途中で失敗した場合も新しいアプライアンスを管理対象とする)
+		return append(diags, resourceSakuraCloudDatabaseRead(ctx, d, meta)...)
+	}
+
 	dbBuilder := expandDatabaseBuilder(d, client)
 	if _, err := dbBuilder.Update(ctx, zone, db.ID); err != nil {
 		return diag.Errorf("updating SakuraCloud Database[%s] is failed: %s", d.Id(), err)
@@ -282,7 +318,8 @@ func setDatabaseResourceData(ctx context.Context, d *schema.ResourceData, client
 		return diag.Errorf("got unexpected state: Database[%d].Availability is failed", data.ID)
 	}
 
-	d.Set("database_type", flattenDatabaseType(data)) // nolint
+	d.Set("database_type", flattenDatabaseType(data))    // nolint
+	d.Set("database_version", data.Conf.DatabaseVersion) // nolint
 	if data.ReplicationSetting != nil {
 		d.Set("replica_user", data.CommonSetting.ReplicaUser)         // nolint
 		d.Set("replica_password", data.CommonSetting.ReplicaPassword) // nolint
@@ -290,7 +327,7 @@ func setDatabaseResourceData(ctx context.Context, d *schema.ResourceData, client
 	if err := d.Set("backup", flattenDatabaseBackupSetting(data)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("tags", flattenDatabaseTags(data)); err != nil {
+	if err := setResourceTags(d, client, filterDatabaseTags(data)); err != nil {
 		return diag.FromErr(err)
 	}
 	d.Set("name", data.Name)                              // nolint
@@ -0,0 +1,141 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func resourceSakuraCloudDatabaseBackup() *schema.Resource {
+	resourceName := "Database Backup"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudDatabaseBackupCreate,
+		ReadContext:   resourceSakuraCloudDatabaseBackupRead,
+		DeleteContext: resourceSakuraCloudDatabaseBackupDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"database_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Database to take a backup",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descf("The date and time the %s was created", resourceName),
+			},
+			"recovered_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descf("The date and time the Database was last restored from the %s", resourceName),
+			},
+			"availability": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descf("The availability of the %s", resourceName),
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: descf("The size of the %s in bytes", resourceName),
+			},
+			"zone": schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudDatabaseBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databaseID := d.Get("database_id").(string)
+	sakuraMutexKV.Lock(databaseID)
+	defer sakuraMutexKV.Unlock(databaseID)
+
+	backup, err := waitForDatabaseBackup(ctx, newDatabaseBackupOp(client), zone, sakuraCloudID(databaseID))
+	if err != nil {
+		return diag.Errorf("creating SakuraCloud DatabaseBackup is failed: %s", err)
+	}
+
+	d.SetId(databaseBackupID(backup))
+	return resourceSakuraCloudDatabaseBackupRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudDatabaseBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	histories, err := newDatabaseBackupOp(client).List(ctx, zone, expandSakuraCloudID(d, "database_id"))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud DatabaseBackup[%s]: %s", d.Id(), err)
+	}
+	backup := findDatabaseBackup(histories, d.Id())
+	if backup == nil {
+		d.SetId("")
+		return nil
+	}
+
+	return setDatabaseBackupResourceData(d, client, backup)
+}
+
+func resourceSakuraCloudDatabaseBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databaseID := d.Get("database_id").(string)
+	sakuraMutexKV.Lock(databaseID)
+	defer sakuraMutexKV.Unlock(databaseID)
+
+	if err := newDatabaseBackupOp(client).Delete(ctx, zone, sakuraCloudID(databaseID), d.Id()); err != nil {
+		if !sacloud.IsNotFoundError(err) {
+			return diag.Errorf("deleting SakuraCloud DatabaseBackup[%s] is failed: %s", d.Id(), err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func setDatabaseBackupResourceData(d *schema.ResourceData, client *APIClient, data *sacloud.DatabaseBackupHistory) diag.Diagnostics {
+	d.Set("created_at", flattenDatabaseBackupTime(data.CreatedAt))     // nolint
+	d.Set("recovered_at", flattenDatabaseBackupTime(data.RecoveredAt)) // nolint
+	d.Set("availability", data.Availability)                           // nolint
+	d.Set("size", data.Size)                                           // nolint
+	d.Set("zone", getZone(d, client))                                  // nolint
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSakuraCloudDatabaseBackup_basic(t *testing.T) {
+	skipIfFakeModeEnabled(t)
+
+	resourceName := "sakuracloud_database_backup.foobar"
+	rand := randomName()
+	password := randomPassword()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudDatabaseDestroy,
+			testCheckSakuraCloudSwitchDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDatabaseBackup_basic, rand, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						resourceName, "database_id",
+						"sakuracloud_database.foobar", "id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "availability", "available"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDatabaseBackup_restore, rand, password),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"sakuracloud_database_restore.foobar", "backup_id",
+						resourceName, "id",
+					),
+					resource.TestCheckResourceAttrSet("sakuracloud_database_restore.foobar", "recovered_at"),
+					resource.TestCheckResourceAttr("data.sakuracloud_database_backup_history.foobar", "backups.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.sakuracloud_database_backup_history.foobar", "backups.0.id",
+						resourceName, "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+const testAccSakuraCloudDatabaseBackup_basic = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_database" "foobar" {
+  database_type = "postgres"
+  plan          = "10g"
+
+  username = "defuser"
+  password = "{{ .arg1 }}"
+
+  network_interface {
+    switch_id  = sakuracloud_switch.foobar.id
+    ip_address = "192.168.153.101"
+    netmask    = 24
+    gateway    = "192.168.153.1"
+  }
+
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_database_backup" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+}
+`
+
+const testAccSakuraCloudDatabaseBackup_restore = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_database" "foobar" {
+  database_type = "postgres"
+  plan          = "10g"
+
+  username = "defuser"
+  password = "{{ .arg1 }}"
+
+  network_interface {
+    switch_id  = sakuracloud_switch.foobar.id
+    ip_address = "192.168.153.101"
+    netmask    = 24
+    gateway    = "192.168.153.1"
+  }
+
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_database_backup" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+}
+
+resource "sakuracloud_database_restore" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+  backup_id   = sakuracloud_database_backup.foobar.id
+}
+
+data "sakuracloud_database_backup_history" "foobar" {
+  database_id = sakuracloud_database.foobar.id
+
+  depends_on = [sakuracloud_database_restore.foobar]
+}
+`
@@ -16,6 +16,7 @@ package sakuracloud
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -32,8 +33,9 @@ func resourceSakuraCloudDatabaseReadReplica() *schema.Resource {
 		ReadContext:   resourceSakuraCloudDatabaseReadReplicaRead,
 		UpdateContext: resourceSakuraCloudDatabaseReadReplicaUpdate,
 		DeleteContext: resourceSakuraCloudDatabaseReadReplicaDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudDatabaseReadReplicaRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -106,7 +108,47 @@ func resourceSakuraCloudDatabaseReadReplica() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
-			"zone":        schemaResourceZone(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
+			"wait_for_replication_sync": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: descf(
+					"The flag to wait until the replication delay of the %s becomes less than or equal to `replication_max_delay_sec` when creating or updating",
+					resourceName,
+				),
+			},
+			"replication_max_delay_sec": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum replication delay in seconds that is considered as synced",
+			},
+			"database_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descf("The status of the database engine running on the %s", resourceName),
+			},
+			"database_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descf("The version tag of the %s", resourceName),
+			},
+			"replication_delay_sec": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The latest replication delay in seconds",
+			},
+			"replication_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: descf(
+					"The status of the replication. This will be one of [%s]",
+					[]string{databaseReplicationStatusSynced, databaseReplicationStatusDelayed, databaseReplicationStatusUnknown},
+				),
+			},
+			"zone": schemaResourceZone(resourceName),
 		},
 	}
 }
@@ -135,6 +177,12 @@ func resourceSakuraCloudDatabaseReadReplicaCreate(ctx context.Context, d *schema
 	// この挙動はテストなどで問題となる。このためここで少しsleepすることで対応する。
 	time.Sleep(client.databaseWaitAfterCreateDuration)
 
+	if d.Get("wait_for_replication_sync").(bool) {
+		if err := waitForDatabaseReplicationSync(ctx, client, zone, db, d.Get("replication_max_delay_sec").(int)); err != nil {
+			return diag.Errorf("waiting for replication sync of SakuraCloud Database ReadReplica[%s] is failed: %s", db.ID, err)
+		}
+	}
+
 	return setDatabaseReadReplicaResourceData(ctx, d, client, db)
 }
 
@@ -180,6 +228,12 @@ func resourceSakuraCloudDatabaseReadReplicaUpdate(ctx context.Context, d *schema
 		return diag.Errorf("updating SakuraCloud Database ReadReplica[%s] is failed: %s", d.Id(), err)
 	}
 
+	if d.Get("wait_for_replication_sync").(bool) {
+		if err := waitForDatabaseReplicationSync(ctx, client, zone, db, d.Get("replication_max_delay_sec").(int)); err != nil {
+			return diag.Errorf("waiting for replication sync of SakuraCloud Database ReadReplica[%s] is failed: %s", db.ID, err)
+		}
+	}
+
 	return setDatabaseReadReplicaResourceData(ctx, d, client, db)
 }
 
@@ -222,7 +276,7 @@ func setDatabaseReadReplicaResourceData(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("got unexpected state: Database[%d].Availability is failed", data.ID)
 	}
 
-	if err := d.Set("tags", flattenDatabaseTags(data)); err != nil {
+	if err := setResourceTags(d, client, filterDatabaseTags(data)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -233,6 +287,94 @@ func setDatabaseReadReplicaResourceData(ctx context.Context, d *schema.ResourceD
 	}
 	d.Set("icon_id", data.IconID.String()) // nolint
 	d.Set("description", data.Description) // nolint
-	d.Set("zone", getZone(d, client))      // nolint
+
+	// ステータスの取得に失敗してもリフレッシュ全体は失敗させず、ステータス関連の属性を空にする
+	status, err := readDatabaseReplicationStatus(ctx, client, getZone(d, client), data, d.Get("replication_max_delay_sec").(int))
+	if err != nil {
+		log.Printf("[WARN] could not read status of SakuraCloud Database ReadReplica[%s]: %s", data.ID, err)
+		status = &databaseReplicationStatus{}
+	}
+	d.Set("database_status", status.databaseStatus)   // nolint
+	d.Set("database_version", status.databaseVersion) // nolint
+	d.Set("replication_delay_sec", status.delaySec)   // nolint
+	d.Set("replication_status", status.status)        // nolint
+	d.Set("zone", getZone(d, client))                 // nolint
 	return nil
 }
+
+const (
+	databaseReplicationStatusSynced  = "synced"
+	databaseReplicationStatusDelayed = "delayed"
+	databaseReplicationStatusUnknown = "unknown"
+)
+
+type databaseReplicationStatus struct {
+	databaseStatus  string
+	databaseVersion string
+	delaySec        float64
+	monitoredAt     time.Time
+	status          string
+}
+
+func readDatabaseReplicationStatus(ctx context.Context, client *APIClient, zone string, db *sacloud.Database, maxDelaySec int) (*databaseReplicationStatus, error) {
+	dbOp := sacloud.NewDatabaseOp(client)
+
+	dbStatus, err := dbOp.Status(ctx, zone, db.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := &databaseReplicationStatus{status: databaseReplicationStatusUnknown}
+	switch flattenDatabaseType(db) {
+	case "postgres":
+		result.databaseStatus = dbStatus.PostgresStatus
+	case "mariadb":
+		result.databaseStatus = dbStatus.MariaDBStatus
+	}
+	if dbStatus.Version != nil {
+		result.databaseVersion = dbStatus.Version.Tag
+	}
+
+	// 作成直後などはモニタリングデータが取得できないことがあるため、エラーとせずunknownとする
+	activity, err := dbOp.MonitorDatabase(ctx, zone, db.ID, &sacloud.MonitorCondition{})
+	if err != nil {
+		log.Printf("[WARN] could not read database activity of SakuraCloud Database[%s]: %s", db.ID, err)
+		return result, nil
+	}
+	_, latest := flattenMonitorDatabaseValues(activity)
+	if latest.Time.IsZero() {
+		return result, nil
+	}
+
+	result.delaySec = latest.DelayTimeSec
+	result.monitoredAt = latest.Time
+	if latest.DelayTimeSec <= float64(maxDelaySec) {
+		result.status = databaseReplicationStatusSynced
+	} else {
+		result.status = databaseReplicationStatusDelayed
+	}
+	return result, nil
+}
+
+// waitForDatabaseReplicationSync 作成後に取得したモニタリングデータでレプリケーション遅延が許容範囲内となるまで待つ
+func waitForDatabaseReplicationSync(ctx context.Context, client *APIClient, zone string, db *sacloud.Database, maxDelaySec int) error {
+	startedAt := time.Now().Truncate(time.Minute)
+
+	ticker := time.NewTicker(sacloud.DefaultDBStatusPollingInterval)
+	defer ticker.Stop()
+	for {
+		status, err := readDatabaseReplicationStatus(ctx, client, zone, db, maxDelaySec)
+		if err != nil {
+			return err
+		}
+		if status.status == databaseReplicationStatusSynced && !status.monitoredAt.Before(startedAt) {
+			return nil
+		}
+		log.Printf("[INFO] waiting for replication sync of SakuraCloud Database ReadReplica[%s]: status=%s delay=%v", db.ID, status.status, status.delaySec)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
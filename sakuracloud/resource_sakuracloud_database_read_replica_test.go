@@ -54,6 +54,8 @@ func TestAccSakuraCloudDatabaseReplica_basic(t *testing.T) {
 						resourceName, "icon_id",
 						"sakuracloud_icon.foobar", "id",
 					),
+					resource.TestCheckResourceAttrSet(resourceName, "database_version"),
+					resource.TestCheckResourceAttrSet(resourceName, "replication_status"),
 				),
 			},
 			{
@@ -0,0 +1,115 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func resourceSakuraCloudDatabaseRestore() *schema.Resource {
+	resourceName := "Database"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudDatabaseRestoreCreate,
+		ReadContext:   resourceSakuraCloudDatabaseRestoreRead,
+		DeleteContext: resourceSakuraCloudDatabaseRestoreDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"database_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Database to restore",
+			},
+			"backup_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the backup to restore from",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of arbitrary values that, when changed, will run the restore again",
+			},
+			"recovered_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the Database was restored",
+			},
+			"zone": schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudDatabaseRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	databaseID := d.Get("database_id").(string)
+	backupID := d.Get("backup_id").(string)
+
+	sakuraMutexKV.Lock(databaseID)
+	defer sakuraMutexKV.Unlock(databaseID)
+
+	backup, err := waitForDatabaseRestore(ctx, newDatabaseBackupOp(client), zone, sakuraCloudID(databaseID), backupID)
+	if err != nil {
+		return diag.Errorf("restoring SakuraCloud Database[%s] from backup[%s] is failed: %s", databaseID, backupID, err)
+	}
+
+	d.SetId(backupID)
+	d.Set("recovered_at", flattenDatabaseBackupTime(backup.RecoveredAt)) // nolint
+	return resourceSakuraCloudDatabaseRestoreRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudDatabaseRestoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// 復元元のバックアップが削除されても再度復元しないよう、データベースの存在のみ確認する
+	dbOp := sacloud.NewDatabaseOp(client)
+	if _, err := dbOp.Read(ctx, zone, expandSakuraCloudID(d, "database_id")); err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud Database[%s]: %s", d.Get("database_id").(string), err)
+	}
+
+	d.Set("zone", getZone(d, client)) // nolint
+	return nil
+}
+
+func resourceSakuraCloudDatabaseRestoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// restore can not be reverted, so we only need to remove it from the state
+	d.SetId("")
+	return nil
+}
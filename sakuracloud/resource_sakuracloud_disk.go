@@ -29,14 +29,15 @@ import (
 )
 
 func resourceSakuraCloudDisk() *schema.Resource {
-	resourceName := "disk"
+	resourceName := "Disk"
 	return &schema.Resource{
 		CreateContext: resourceSakuraCloudDiskCreate,
 		ReadContext:   resourceSakuraCloudDiskRead,
 		UpdateContext: resourceSakuraCloudDiskUpdate,
 		DeleteContext: resourceSakuraCloudDiskDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudDiskRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -93,6 +94,7 @@ func resourceSakuraCloudDisk() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 		},
 	}
@@ -108,7 +110,7 @@ func resourceSakuraCloudDiskCreate(ctx context.Context, d *schema.ResourceData,
 	diskBuilder := &setup.RetryableSetup{
 		IsWaitForCopy: true,
 		Create: func(ctx context.Context, zone string) (accessor.ID, error) {
-			return diskOp.Create(ctx, zone, expandDiskCreateRequest(d), expandSakuraCloudIDs(d, "distant_from"))
+			return diskOp.Create(ctx, zone, expandDiskCreateRequest(d, client), expandSakuraCloudIDs(d, "distant_from"))
 		},
 		Read: func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
 			return diskOp.Read(ctx, zone, id)
@@ -164,7 +166,7 @@ func resourceSakuraCloudDiskUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("could not read SakuraCloud Disk[%s]: %s", d.Id(), err)
 	}
 
-	_, err = diskOp.Update(ctx, zone, disk.ID, expandDiskUpdateRequest(d))
+	_, err = diskOp.Update(ctx, zone, disk.ID, expandDiskUpdateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud Disk[%s] is failed: %s", d.Id(), err)
 	}
@@ -206,5 +208,5 @@ func setDiskResourceData(ctx context.Context, d *schema.ResourceData, client *AP
 	d.Set("description", data.Description)                    // nolint
 	d.Set("server_id", data.ServerID.String())                // nolint
 	d.Set("zone", getZone(d, client))                         // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
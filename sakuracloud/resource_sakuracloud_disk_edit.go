@@ -0,0 +1,276 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	diskBuilder "github.com/sacloud/libsacloud/v2/helper/builder/disk"
+	"github.com/sacloud/libsacloud/v2/helper/power"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+var diskEditParameterKeys = []string{
+	"hostname",
+	"password",
+	"ssh_key_ids",
+	"ssh_keys",
+	"disable_pw_auth",
+	"enable_dhcp",
+	"change_partition_uuid",
+	"note",
+	"ip_address",
+	"gateway",
+	"netmask",
+}
+
+func resourceSakuraCloudDiskEdit() *schema.Resource {
+	resourceName := "Disk"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudDiskEditCreate,
+		ReadContext:   resourceSakuraCloudDiskEditRead,
+		UpdateContext: resourceSakuraCloudDiskEditUpdate,
+		DeleteContext: resourceSakuraCloudDiskEditDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"disk_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the disk to edit",
+			},
+			"hostname": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 64)),
+				Description:      descf("The hostname to set to the %s. %s", resourceName, descLength(1, 64)),
+			},
+			"password": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(8, 64)),
+				Sensitive:        true,
+				Description:      descf("The password of default user. %s", descLength(8, 64)),
+			},
+			"ssh_key_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of the SSHKey id",
+			},
+			"ssh_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of the SSHKey text",
+			},
+			"disable_pw_auth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "The flag to disable password authentication",
+			},
+			"enable_dhcp": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "The flag to enable DHCP client",
+			},
+			"change_partition_uuid": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "The flag to change partition uuid",
+			},
+			"note": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+							Description:      "The id of the note",
+						},
+						"api_key_id": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+							Description:      "The id of the API key to be injected into note when editing the disk",
+						},
+						"variables": {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:    true,
+							Description: "The value of the variable that be injected into note when editing the disk",
+						},
+					},
+				},
+				Description: "A list of the Note/StartupScript",
+			},
+			"ip_address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPv4Address),
+				Description:      "The IP address to assign to the Server",
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The gateway address used by the Server",
+			},
+			"netmask": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The bit length of the subnet to assign to the Server",
+			},
+			"force_shutdown": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "The flag to use force shutdown when need to shutdown the server connected to the disk while editing",
+			},
+			"server_id": schemaDataSourceServerID(resourceName),
+			"zone":      schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudDiskEditCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diskOp := sacloud.NewDiskOp(client)
+	disk, err := diskOp.Read(ctx, zone, expandSakuraCloudID(d, "disk_id"))
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Disk[%s]: %s", d.Get("disk_id").(string), err)
+	}
+
+	if err := editSakuraCloudDisk(ctx, d, client, zone, disk); err != nil {
+		return diag.Errorf("editing SakuraCloud Disk[%s] is failed: %s", disk.ID, err)
+	}
+
+	d.SetId(disk.ID.String())
+	return resourceSakuraCloudDiskEditRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudDiskEditRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diskOp := sacloud.NewDiskOp(client)
+	disk, err := diskOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud Disk[%s]: %s", d.Id(), err)
+	}
+
+	d.Set("disk_id", disk.ID.String())         // nolint
+	d.Set("server_id", disk.ServerID.String()) // nolint
+	d.Set("zone", getZone(d, client))          // nolint
+	return nil
+}
+
+func resourceSakuraCloudDiskEditUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diskOp := sacloud.NewDiskOp(client)
+	disk, err := diskOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Disk[%s]: %s", d.Id(), err)
+	}
+
+	if d.HasChanges(diskEditParameterKeys...) {
+		if err := editSakuraCloudDisk(ctx, d, client, zone, disk); err != nil {
+			return diag.Errorf("editing SakuraCloud Disk[%s] is failed: %s", disk.ID, err)
+		}
+	}
+
+	return resourceSakuraCloudDiskEditRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudDiskEditDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// disk edit can not be reverted, so we only need to remove it from the state
+	d.SetId("")
+	return nil
+}
+
+func editSakuraCloudDisk(ctx context.Context, d *schema.ResourceData, client *APIClient, zone string, disk *sacloud.Disk) (err error) {
+	builder := &diskBuilder.ConnectedDiskBuilder{
+		ID:            disk.ID,
+		Name:          disk.Name,
+		Description:   disk.Description,
+		Tags:          disk.Tags,
+		IconID:        disk.IconID,
+		Connection:    disk.Connection,
+		EditParameter: expandDiskEditParameter(d),
+		Client:        diskBuilder.NewBuildersAPIClient(client),
+	}
+	if err := builder.Validate(ctx, zone); err != nil {
+		return err
+	}
+
+	if disk.ServerID.IsEmpty() {
+		_, err := builder.Update(ctx, zone)
+		return err
+	}
+
+	// the server connected to the disk must be stopped while editing
+	sakuraMutexKV.Lock(disk.ServerID.String())
+	defer sakuraMutexKV.Unlock(disk.ServerID.String())
+
+	serverOp := sacloud.NewServerOp(client)
+	server, err := serverOp.Read(ctx, zone, disk.ServerID)
+	if err != nil {
+		return err
+	}
+
+	if server.InstanceStatus.IsUp() {
+		if err := power.ShutdownServer(ctx, serverOp, zone, server.ID, d.Get("force_shutdown").(bool)); err != nil {
+			return err
+		}
+		// 編集に失敗した場合もサーバを停止したままにしないよう、結果に関わらず起動する
+		defer func() {
+			if bootErr := power.BootServer(ctx, serverOp, zone, server.ID); bootErr != nil {
+				err = multierror.Append(err, fmt.Errorf("booting SakuraCloud Server[%s] is failed: %s", server.ID, bootErr))
+			}
+		}()
+	}
+
+	_, err = builder.Update(ctx, zone)
+	return err
+}
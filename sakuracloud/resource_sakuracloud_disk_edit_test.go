@@ -0,0 +1,130 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func TestAccSakuraCloudDiskEdit_basic(t *testing.T) {
+	resourceName := "sakuracloud_disk_edit.foobar"
+	rand := randomName()
+	password := randomPassword()
+
+	var disk sacloud.Disk
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudDiskDestroy,
+			testCheckSakuraCloudServerDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDiskEdit_basic, rand, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDiskExists("sakuracloud_disk.foobar", &disk),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "disk_id",
+						"sakuracloud_disk.foobar", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "server_id",
+						"sakuracloud_server.foobar", "id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "hostname", rand),
+					resource.TestCheckResourceAttr(resourceName, "password", password),
+					resource.TestCheckResourceAttr(resourceName, "ssh_keys.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "ssh_keys.0", "ssh-rsa xxxxx"),
+					resource.TestCheckResourceAttr(resourceName, "disable_pw_auth", "true"),
+				),
+			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudDiskEdit_update, rand, password),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudDiskExists("sakuracloud_disk.foobar", &disk),
+					resource.TestCheckResourceAttr(resourceName, "hostname", rand+"-upd"),
+					resource.TestCheckResourceAttr(resourceName, "ssh_keys.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ssh_keys.1", "ssh-rsa yyyyy"),
+					resource.TestCheckResourceAttr(resourceName, "disable_pw_auth", "false"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudDiskEdit_basic = `
+data "sakuracloud_archive" "ubuntu" {
+  os_type = "ubuntu2004"
+}
+
+resource "sakuracloud_disk" "foobar" {
+  name              = "{{ .arg0 }}"
+  source_archive_id = data.sakuracloud_archive.ubuntu.id
+}
+
+resource "sakuracloud_server" "foobar" {
+  name  = "{{ .arg0 }}"
+  disks = [sakuracloud_disk.foobar.id]
+  network_interface {
+    upstream = "shared"
+  }
+  force_shutdown = true
+}
+
+resource "sakuracloud_disk_edit" "foobar" {
+  disk_id         = sakuracloud_disk.foobar.id
+  hostname        = "{{ .arg0 }}"
+  password        = "{{ .arg1 }}"
+  ssh_keys        = ["ssh-rsa xxxxx"]
+  disable_pw_auth = true
+  force_shutdown  = true
+
+  depends_on = [sakuracloud_server.foobar]
+}
+`
+
+var testAccSakuraCloudDiskEdit_update = `
+data "sakuracloud_archive" "ubuntu" {
+  os_type = "ubuntu2004"
+}
+
+resource "sakuracloud_disk" "foobar" {
+  name              = "{{ .arg0 }}"
+  source_archive_id = data.sakuracloud_archive.ubuntu.id
+}
+
+resource "sakuracloud_server" "foobar" {
+  name  = "{{ .arg0 }}"
+  disks = [sakuracloud_disk.foobar.id]
+  network_interface {
+    upstream = "shared"
+  }
+  force_shutdown = true
+}
+
+resource "sakuracloud_disk_edit" "foobar" {
+  disk_id        = sakuracloud_disk.foobar.id
+  hostname       = "{{ .arg0 }}-upd"
+  password       = "{{ .arg1 }}"
+  ssh_keys       = ["ssh-rsa xxxxx", "ssh-rsa yyyyy"]
+  force_shutdown = true
+
+  depends_on = [sakuracloud_server.foobar]
+}
+`
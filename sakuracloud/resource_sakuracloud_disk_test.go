@@ -193,6 +193,22 @@ func TestAccImportSakuraCloudDisk_basic(t *testing.T) {
 					"distant_from",
 				},
 			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", resourceName)
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["zone"], rs.Primary.ID), nil
+				},
+				ImportStateCheck:  checkFn,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"distant_from",
+				},
+			},
 		},
 	})
 }
@@ -32,8 +32,9 @@ func resourceSakuraCloudDNS() *schema.Resource {
 		ReadContext:   resourceSakuraCloudDNSRead,
 		UpdateContext: resourceSakuraCloudDNSUpdate,
 		DeleteContext: resourceSakuraCloudDNSDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudDNSRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -107,6 +108,7 @@ func resourceSakuraCloudDNS() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 		},
 	}
 }
@@ -115,7 +117,7 @@ func resourceSakuraCloudDNSCreate(ctx context.Context, d *schema.ResourceData, m
 	client := meta.(*APIClient)
 
 	dnsOp := sacloud.NewDNSOp(client)
-	dns, err := dnsOp.Create(ctx, expandDNSCreateRequest(d))
+	dns, err := dnsOp.Create(ctx, expandDNSCreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud DNS is failed: %s", err)
 	}
@@ -152,7 +154,7 @@ func resourceSakuraCloudDNSUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("could not read SakuraCloud DNS[%s]: %s", d.Id(), err)
 	}
 
-	if _, err := dnsOp.Update(ctx, dns.ID, expandDNSUpdateRequest(d, dns)); err != nil {
+	if _, err := dnsOp.Update(ctx, dns.ID, expandDNSUpdateRequest(d, client, dns)); err != nil {
 		return diag.Errorf("updating SakuraCloud DNS[%s] is failed: %s", d.Id(), err)
 	}
 	return resourceSakuraCloudDNSRead(ctx, d, meta)
@@ -190,5 +192,5 @@ func setDNSResourceData(ctx context.Context, d *schema.ResourceData, client *API
 	if err := d.Set("record", flattenDNSRecords(data)); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
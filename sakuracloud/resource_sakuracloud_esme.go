@@ -31,8 +31,9 @@ func resourceSakuraCloudESME() *schema.Resource {
 		ReadContext:   resourceSakuraCloudESMERead,
 		UpdateContext: resourceSakuraCloudESMEUpdate,
 		DeleteContext: resourceSakuraCloudESMEDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudESMERead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -46,6 +47,7 @@ func resourceSakuraCloudESME() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"send_message_with_generated_otp_api_url": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -68,7 +70,7 @@ func resourceSakuraCloudESMECreate(ctx context.Context, d *schema.ResourceData,
 
 	esmeOp := sacloud.NewESMEOp(client)
 
-	esme, err := esmeOp.Create(ctx, expandESMECreateRequest(d))
+	esme, err := esmeOp.Create(ctx, expandESMECreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud ESME is failed: %s", err)
 	}
@@ -111,7 +113,7 @@ func resourceSakuraCloudESMEUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	if _, err = esmeOp.Update(ctx, esme.ID, expandESMEUpdateRequest(d, esme)); err != nil {
+	if _, err = esmeOp.Update(ctx, esme.ID, expandESMEUpdateRequest(d, client, esme)); err != nil {
 		return diag.Errorf("updating SakuraCloud ESME[%s] is failed: %s", d.Id(), err)
 	}
 
@@ -142,7 +144,7 @@ func resourceSakuraCloudESMEDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func setESMEResourceData(d *schema.ResourceData, _ *APIClient, data *sacloud.ESME) diag.Diagnostics {
+func setESMEResourceData(d *schema.ResourceData, client *APIClient, data *sacloud.ESME) diag.Diagnostics {
 	d.Set("name", data.Name)                         // nolint
 	d.Set("icon_id", data.IconID.String())           // nolint
 	d.Set("description", data.Description)           // nolint
@@ -162,5 +164,5 @@ func setESMEResourceData(d *schema.ResourceData, _ *APIClient, data *sacloud.ESM
 			d.Id(),
 		),
 	)
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -33,8 +33,9 @@ func resourceSakuraCloudGSLB() *schema.Resource {
 		ReadContext:   resourceSakuraCloudGSLBRead,
 		UpdateContext: resourceSakuraCloudGSLBUpdate,
 		DeleteContext: resourceSakuraCloudGSLBDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudGSLBRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -140,6 +141,7 @@ func resourceSakuraCloudGSLB() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 		},
 	}
 }
@@ -151,7 +153,7 @@ func resourceSakuraCloudGSLBCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	gslbOp := sacloud.NewGSLBOp(client)
-	gslb, err := gslbOp.Create(ctx, expandGSLBCreateRequest(d))
+	gslb, err := gslbOp.Create(ctx, expandGSLBCreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud GSLB is failed: %s", err)
 	}
@@ -191,7 +193,7 @@ func resourceSakuraCloudGSLBUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("could not read SakuraCloud GSLB[%s]: %s", d.Id(), err)
 	}
 
-	_, err = gslbOp.Update(ctx, sakuraCloudID(d.Id()), expandGSLBUpdateRequest(d, gslb))
+	_, err = gslbOp.Update(ctx, sakuraCloudID(d.Id()), expandGSLBUpdateRequest(d, client, gslb))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud GSLB[%s] is failed: %s", d.Id(), err)
 	}
@@ -234,5 +236,5 @@ func setGSLBResourceData(ctx context.Context, d *schema.ResourceData, client *AP
 	if err := d.Set("server", flattenGSLBServers(data)); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -30,6 +30,7 @@ func resourceSakuraCloudIcon() *schema.Resource {
 		ReadContext:   resourceSakuraCloudIconRead,
 		UpdateContext: resourceSakuraCloudIconUpdate,
 		DeleteContext: resourceSakuraCloudIconDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
@@ -59,7 +60,8 @@ func resourceSakuraCloudIcon() *schema.Resource {
 					descConflicts("source"),
 				),
 			},
-			"tags": schemaResourceTags(resourceName),
+			"tags":     schemaResourceTags(resourceName),
+			"tags_all": schemaResourceTagsAll(resourceName),
 			"url": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -77,7 +79,7 @@ func resourceSakuraCloudIconCreate(ctx context.Context, d *schema.ResourceData,
 
 	iconOp := sacloud.NewIconOp(client)
 
-	req, err := expandIconCreateRequest(d)
+	req, err := expandIconCreateRequest(d, client)
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud Icon is failed: %s", err)
 	}
@@ -121,7 +123,7 @@ func resourceSakuraCloudIconUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("could not read SakuraCloud Icon[%s]: %s", d.Id(), err)
 	}
 
-	_, err = iconOp.Update(ctx, sakuraCloudID(d.Id()), expandIconUpdateRequest(d))
+	_, err = iconOp.Update(ctx, sakuraCloudID(d.Id()), expandIconUpdateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud Icon[%s] is failed: %s", d.Id(), err)
 	}
@@ -153,5 +155,5 @@ func resourceSakuraCloudIconDelete(ctx context.Context, d *schema.ResourceData,
 func setIconResourceData(ctx context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.Icon) diag.Diagnostics {
 	d.Set("name", data.Name) // nolint
 	d.Set("url", data.URL)   // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
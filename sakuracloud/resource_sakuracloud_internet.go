@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sacloud/libsacloud/v2/helper/cleanup"
@@ -35,8 +36,17 @@ func resourceSakuraCloudInternet() *schema.Resource {
 		ReadContext:   resourceSakuraCloudInternetRead,
 		UpdateContext: resourceSakuraCloudInternetUpdate,
 		DeleteContext: resourceSakuraCloudInternetDelete,
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf("router_id", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("band_width")
+			}),
+			customdiff.ComputedIf("router_id_history", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("band_width")
+			}),
+			customizeDiffTagsAll,
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudInternetRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -50,6 +60,7 @@ func resourceSakuraCloudInternet() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 			"netmask": {
 				Type:             schema.TypeInt,
@@ -77,6 +88,17 @@ func resourceSakuraCloudInternet() *schema.Resource {
 				Optional:    true,
 				Description: "The flag to enable IPv6",
 			},
+			"router_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descf("The id of the current router. This is changed when the `band_width` is changed, while the id of the %s is kept", resourceName),
+			},
+			"router_id_history": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of the ID of routers which were replaced by changing the `band_width`, in order from oldest to newest",
+			},
 			"switch_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -160,7 +182,11 @@ func resourceSakuraCloudInternetRead(ctx context.Context, d *schema.ResourceData
 
 	internetOp := sacloud.NewInternetOp(client)
 
-	internet, err := internetOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	internet, err := internetOp.Read(ctx, zone, expandInternetRouterID(d))
+	if err != nil && sacloud.IsNotFoundError(err) {
+		// router_idを記録する前に帯域変更が中断された場合に備え、スイッチに記録したリソースIDからも探す
+		internet, err = readInternet(ctx, client, zone, sakuraCloudID(d.Id()))
+	}
 	if err != nil {
 		if sacloud.IsNotFoundError(err) {
 			d.SetId("")
@@ -182,18 +208,37 @@ func resourceSakuraCloudInternetUpdate(ctx context.Context, d *schema.ResourceDa
 	sakuraMutexKV.Lock(d.Id())
 	defer sakuraMutexKV.Unlock(d.Id())
 
-	internet, err := internetOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	internet, err := internetOp.Read(ctx, zone, expandInternetRouterID(d))
 	if err != nil {
 		return diag.Errorf("could not read SakuraCloud Internet[%s]: %s", d.Id(), err)
 	}
 
+	// 帯域変更はルータのIDが変更になるため、他の項目の更新より先に行いrouter_idを記録しておく
+	if d.HasChange("band_width") {
+		previousID := internet.ID
+		internet, err = internetOp.UpdateBandWidth(ctx, zone, previousID, &sacloud.InternetUpdateBandWidthRequest{
+			BandWidthMbps: d.Get("band_width").(int),
+		})
+		if err != nil {
+			return diag.Errorf("updating bandwidth of SakuraCloud Internet[%s] is failed: %s", d.Id(), err)
+		}
+
+		history := flattenInternetRouterIDHistory(d.Get("router_id_history").([]interface{}), previousID, internet.ID)
+		if err := d.Set("router_id_history", history); err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("router_id", internet.ID.String()) // nolint
+
+		if err := recordInternetID(ctx, client, zone, sakuraCloudID(d.Id()), internet); err != nil {
+			return diag.Errorf("recording id of SakuraCloud Internet[%s] to the Switch is failed: %s", d.Id(), err)
+		}
+	}
+
 	builder := expandInternetBuilder(d, client)
-	internet, err = builder.Update(ctx, zone, internet.ID)
-	if err != nil {
+	if _, err := builder.Update(ctx, zone, internet.ID); err != nil {
 		return diag.Errorf("updating SakuraCloud Internet[%s] is failed: %s", d.Id(), err)
 	}
 
-	d.SetId(internet.ID.String()) // 帯域変更後はIDが変更になるため
 	return resourceSakuraCloudInternetRead(ctx, d, meta)
 }
 
@@ -208,7 +253,7 @@ func resourceSakuraCloudInternetDelete(ctx context.Context, d *schema.ResourceDa
 	sakuraMutexKV.Lock(d.Id())
 	defer sakuraMutexKV.Unlock(d.Id())
 
-	internet, err := internetOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	internet, err := internetOp.Read(ctx, zone, expandInternetRouterID(d))
 	if err != nil {
 		if sacloud.IsNotFoundError(err) {
 			d.SetId("")
@@ -262,6 +307,7 @@ func setInternetResourceData(ctx context.Context, d *schema.ResourceData, client
 	d.Set("description", data.Description)                      // nolint
 	d.Set("netmask", data.NetworkMaskLen)                       // nolint
 	d.Set("band_width", data.BandWidthMbps)                     // nolint
+	d.Set("router_id", data.ID.String())                        // nolint
 	d.Set("switch_id", sw.ID.String())                          // nolint
 	d.Set("network_address", sw.Subnets[0].NetworkAddress)      // nolint
 	d.Set("gateway", sw.Subnets[0].DefaultRoute)                // nolint
@@ -278,5 +324,5 @@ func setInternetResourceData(ctx context.Context, d *schema.ResourceData, client
 	if err := d.Set("server_ids", serverIDs); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -0,0 +1,388 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func resourceSakuraCloudInternetPtrRecords() *schema.Resource {
+	resourceName := "Internet PTR Records"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudInternetPtrRecordsCreate,
+		ReadContext:   resourceSakuraCloudInternetPtrRecordsRead,
+		UpdateContext: resourceSakuraCloudInternetPtrRecordsUpdate,
+		DeleteContext: resourceSakuraCloudInternetPtrRecordsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSakuraCloudInternetPtrRecordsImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"internet_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"internet_id", "subnet_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Internet(Switch+Router). The PTR records are set to the IP addresses of the primary subnet of the Internet",
+			},
+			"subnet_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"internet_id", "subnet_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Subnet. The PTR records are set to the IP addresses of the Subnet",
+			},
+			"record": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateIPv4Address(),
+							Description:      "The IP address to which the PTR record is set",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The value of the PTR record. This must be FQDN",
+						},
+					},
+				},
+				Description: "One or more `record` blocks",
+			},
+			"verify_forward_dns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "The flag to verify that the hostname is resolved to the IP address before setting each PTR record",
+			},
+			"zone": schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudInternetPtrRecordsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, addresses, err := readInternetPtrRecordsTarget(ctx, d, client, zone)
+	if err != nil {
+		return diag.Errorf("creating SakuraCloud Internet PTR Records is failed: %s", err)
+	}
+	desired := expandInternetPtrRecords(d.Get("record").(*schema.Set).List())
+	if err := validateInternetPtrRecords(desired, addresses); err != nil {
+		return diag.Errorf("creating SakuraCloud Internet PTR Records is failed: %s", err)
+	}
+
+	sakuraMutexKV.Lock(id)
+	defer sakuraMutexKV.Unlock(id)
+
+	current, err := listInternetPtrRecords(ctx, client, zone)
+	if err != nil {
+		return diag.Errorf("creating SakuraCloud Internet PTR Records is failed: %s", err)
+	}
+
+	d.SetId(id)
+	diags := applyInternetPtrRecords(ctx, sacloud.NewIPAddressOp(client), zone, current, nil, desired, d.Get("verify_forward_dns").(bool))
+	return append(diags, resourceSakuraCloudInternetPtrRecordsRead(ctx, d, meta)...)
+}
+
+func resourceSakuraCloudInternetPtrRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, addresses, err := readInternetPtrRecordsTarget(ctx, d, client, zone)
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud Internet PTR Records[%s]: %s", d.Id(), err)
+	}
+
+	current, err := listInternetPtrRecords(ctx, client, zone)
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Internet PTR Records[%s]: %s", d.Id(), err)
+	}
+
+	// インポート時はサブネット内でホスト名が設定されている全てのIPアドレスを対象とする
+	managed := addresses
+	if v, ok := d.GetOk("record"); ok {
+		managed = nil
+		for ip := range expandInternetPtrRecords(v.(*schema.Set).List()) {
+			managed = append(managed, ip)
+		}
+	}
+	return setInternetPtrRecordsResourceData(ctx, d, client, flattenInternetPtrRecords(managed, current))
+}
+
+func resourceSakuraCloudInternetPtrRecordsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, addresses, err := readInternetPtrRecordsTarget(ctx, d, client, zone)
+	if err != nil {
+		return diag.Errorf("updating SakuraCloud Internet PTR Records[%s] is failed: %s", d.Id(), err)
+	}
+	o, n := d.GetChange("record")
+	previous := expandInternetPtrRecords(o.(*schema.Set).List())
+	desired := expandInternetPtrRecords(n.(*schema.Set).List())
+	if err := validateInternetPtrRecords(desired, addresses); err != nil {
+		return diag.Errorf("updating SakuraCloud Internet PTR Records[%s] is failed: %s", d.Id(), err)
+	}
+
+	sakuraMutexKV.Lock(d.Id())
+	defer sakuraMutexKV.Unlock(d.Id())
+
+	current, err := listInternetPtrRecords(ctx, client, zone)
+	if err != nil {
+		return diag.Errorf("updating SakuraCloud Internet PTR Records[%s] is failed: %s", d.Id(), err)
+	}
+
+	diags := applyInternetPtrRecords(ctx, sacloud.NewIPAddressOp(client), zone, current, previous, desired, d.Get("verify_forward_dns").(bool))
+	return append(diags, resourceSakuraCloudInternetPtrRecordsRead(ctx, d, meta)...)
+}
+
+func resourceSakuraCloudInternetPtrRecordsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sakuraMutexKV.Lock(d.Id())
+	defer sakuraMutexKV.Unlock(d.Id())
+
+	current, err := listInternetPtrRecords(ctx, client, zone)
+	if err != nil {
+		return diag.Errorf("deleting SakuraCloud Internet PTR Records[%s] is failed: %s", d.Id(), err)
+	}
+
+	previous := expandInternetPtrRecords(d.Get("record").(*schema.Set).List())
+	diags := applyInternetPtrRecords(ctx, sacloud.NewIPAddressOp(client), zone, current, previous, nil, false)
+	if len(diags) > 0 {
+		// 削除に失敗したレコードが残っている場合はリソースを残す
+		for i := range diags {
+			diags[i].Severity = diag.Error
+		}
+		return diags
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceSakuraCloudInternetPtrRecordsImport IDがスイッチ+ルータ/サブネットのどちらを指すか判定してinternet_id/subnet_idを設定する
+//
+// IDは<zone>/<id>の形式も受け付ける
+func resourceSakuraCloudInternetPtrRecordsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*APIClient)
+	if zone, id, ok := parseZonedID(d.Id()); ok {
+		if err := client.validateZone("zone", zone); err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		d.Set("zone", zone) // nolint
+	}
+	zone := getZone(d, client)
+
+	_, err := readInternet(ctx, client, zone, sakuraCloudID(d.Id()))
+	if err == nil {
+		d.Set("internet_id", d.Id()) // nolint
+		return []*schema.ResourceData{d}, nil
+	}
+	if !sacloud.IsNotFoundError(err) {
+		return nil, fmt.Errorf("could not read SakuraCloud Internet[%s]: %s", d.Id(), err)
+	}
+
+	if _, err := sacloud.NewSubnetOp(client).Read(ctx, zone, sakuraCloudID(d.Id())); err != nil {
+		return nil, fmt.Errorf("could not find SakuraCloud Internet or Subnet[%s] in zone %q: %s", d.Id(), zone, err)
+	}
+	d.Set("subnet_id", d.Id()) // nolint
+	return []*schema.ResourceData{d}, nil
+}
+
+// readInternetPtrRecordsTarget 対象のスイッチ+ルータ/サブネットのIDと、割り当て可能なIPアドレスのリストを返す
+func readInternetPtrRecordsTarget(ctx context.Context, d resourceValueGettable, client *APIClient, zone string) (string, []string, error) {
+	if internetID := d.Get("internet_id").(string); internetID != "" {
+		internet, err := readInternet(ctx, client, zone, sakuraCloudID(internetID))
+		if err != nil {
+			return "", nil, err
+		}
+		sw, err := sacloud.NewSwitchOp(client).Read(ctx, zone, internet.Switch.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(sw.Subnets) == 0 {
+			return "", nil, fmt.Errorf("Switch[%s] of Internet[%s] has no subnet", sw.ID, internetID)
+		}
+		return internetID, sw.Subnets[0].GetAssignedIPAddresses(), nil
+	}
+
+	subnetID := d.Get("subnet_id").(string)
+	subnet, err := sacloud.NewSubnetOp(client).Read(ctx, zone, sakuraCloudID(subnetID))
+	if err != nil {
+		return "", nil, err
+	}
+	var addresses []string
+	for _, ip := range subnet.IPAddresses {
+		addresses = append(addresses, ip.IPAddress)
+	}
+	return subnetID, addresses, nil
+}
+
+// listInternetPtrRecords ゾーン内の全IPアドレスについてIPアドレスからホスト名へのマップを返す
+func listInternetPtrRecords(ctx context.Context, client *APIClient, zone string) (map[string]string, error) {
+	searched, err := sacloud.NewIPAddressOp(client).List(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]string)
+	for _, ip := range searched.IPAddress {
+		results[ip.IPAddress] = ip.HostName
+	}
+	return results, nil
+}
+
+func validateInternetPtrRecords(records map[string]string, addresses []string) error {
+	valid := make(map[string]bool)
+	for _, ip := range addresses {
+		valid[ip] = true
+	}
+	var invalid []string
+	for ip := range records {
+		if !valid[ip] {
+			invalid = append(invalid, ip)
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("IP addresses %q are not assignable addresses of the target", invalid)
+	}
+	return nil
+}
+
+// internetPtrLookupIPAddr 正引きの確認に用いるリゾルバ
+var internetPtrLookupIPAddr = net.DefaultResolver.LookupIPAddr
+
+func verifyInternetPtrForwardDNS(ctx context.Context, ip, hostname string) error {
+	addrs, err := internetPtrLookupIPAddr(ctx, strings.TrimSuffix(hostname, "."))
+	if err != nil {
+		return fmt.Errorf("could not resolve %q: %s", hostname, err)
+	}
+	for _, addr := range addrs {
+		if addr.IP.String() == ip {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not resolved to %s", hostname, ip)
+}
+
+// applyInternetPtrRecords previousからdesiredへPTRレコードを更新する
+//
+// IPアドレスごとの失敗はリソース全体を失敗させずに警告として返す
+func applyInternetPtrRecords(ctx context.Context, ipAddrOp sacloud.IPAddressAPI, zone string, current, previous, desired map[string]string, verify bool) diag.Diagnostics {
+	targets := make(map[string]string)
+	for ip := range previous {
+		targets[ip] = ""
+	}
+	for ip, hostname := range desired {
+		targets[ip] = hostname
+	}
+
+	var ips []string
+	for ip := range targets {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+
+	var diags diag.Diagnostics
+	for _, ip := range ips {
+		hostname := targets[ip]
+		if current[ip] == hostname {
+			continue
+		}
+		if hostname != "" && verify {
+			if err := verifyInternetPtrForwardDNS(ctx, ip, hostname); err != nil {
+				diags = append(diags, internetPtrRecordsWarning(ip, hostname, err))
+				continue
+			}
+		}
+		if _, err := ipAddrOp.UpdateHostName(ctx, zone, ip, hostname); err != nil {
+			diags = append(diags, internetPtrRecordsWarning(ip, hostname, err))
+		}
+	}
+	return diags
+}
+
+func internetPtrRecordsWarning(ip, hostname string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("could not update SakuraCloud IPv4Ptr[%s]", ip),
+		Detail:   fmt.Sprintf("setting hostname %q to %s is failed: %s", hostname, ip, err),
+	}
+}
+
+func expandInternetPtrRecords(records []interface{}) map[string]string {
+	results := make(map[string]string)
+	for _, r := range records {
+		v := mapToResourceData(r.(map[string]interface{}))
+		results[v.Get("ip_address").(string)] = v.Get("hostname").(string)
+	}
+	return results
+}
+
+func flattenInternetPtrRecords(managed []string, current map[string]string) []interface{} {
+	var results []interface{}
+	for _, ip := range managed {
+		if hostname := current[ip]; hostname != "" {
+			results = append(results, map[string]interface{}{
+				"ip_address": ip,
+				"hostname":   hostname,
+			})
+		}
+	}
+	return results
+}
+
+func setInternetPtrRecordsResourceData(_ context.Context, d *schema.ResourceData, client *APIClient, records []interface{}) diag.Diagnostics {
+	d.Set("zone", getZone(d, client)) // nolint
+	return diag.FromErr(d.Set("record", records))
+}
@@ -0,0 +1,267 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSakuraCloudInternetPtrRecords_basic(t *testing.T) {
+	skipIfFakeModeEnabled(t)
+
+	domain, ok := os.LookupEnv(envTestDomain)
+	if !ok {
+		t.Skipf("ENV %q is requilred. skip", envTestDomain)
+		return
+	}
+	resourceName := "sakuracloud_internet_ptr_records.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckSakuraCloudInternetPtrRecordsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudInternetPtrRecords_basic, rand, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudInternetPtrRecordsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "2"),
+				),
+			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudInternetPtrRecords_update, rand, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudInternetPtrRecordsExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "record.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckSakuraCloudInternetPtrRecordsExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return errors.New("no Internet PTR Records ID is set")
+		}
+
+		client := testAccProvider.Meta().(*APIClient)
+		zone := rs.Primary.Attributes["zone"]
+		current, err := listInternetPtrRecords(context.Background(), client, zone)
+		if err != nil {
+			return err
+		}
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasSuffix(k, ".ip_address") {
+				if current[v] == "" {
+					return fmt.Errorf("hostname is empty IPv4Ptr: %s", v)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func testCheckSakuraCloudInternetPtrRecordsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*APIClient)
+	ipAddrOp := sacloud.NewIPAddressOp(client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sakuracloud_internet_ptr_records" {
+			continue
+		}
+
+		zone := rs.Primary.Attributes["zone"]
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasSuffix(k, ".ip_address") {
+				ip, err := ipAddrOp.Read(context.Background(), zone, v)
+				if err == nil && ip.HostName != "" {
+					return fmt.Errorf("still exists IPv4Ptr: %s", ip.IPAddress)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type dummyInternetPtrIPAddressOp struct {
+	sacloud.IPAddressAPI
+	hostnames map[string]string
+	failures  map[string]bool
+}
+
+func (o *dummyInternetPtrIPAddressOp) UpdateHostName(_ context.Context, _ string, ip string, hostname string) (*sacloud.IPAddress, error) {
+	if o.failures[ip] {
+		return nil, fmt.Errorf("dummy error: %s", ip)
+	}
+	o.hostnames[ip] = hostname
+	return &sacloud.IPAddress{IPAddress: ip, HostName: hostname}, nil
+}
+
+func TestInternetPtrRecords_applyInternetPtrRecords(t *testing.T) {
+	defer func(f func(context.Context, string) ([]net.IPAddr, error)) { internetPtrLookupIPAddr = f }(internetPtrLookupIPAddr)
+	internetPtrLookupIPAddr = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		switch host {
+		case "www1.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("192.0.2.11")}}, nil
+		case "www2.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("192.0.2.12")}}, nil
+		case "www3.example.com":
+			return []net.IPAddr{{IP: net.ParseIP("192.0.2.99")}}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	op := &dummyInternetPtrIPAddressOp{
+		hostnames: map[string]string{
+			"192.0.2.10": "old.example.com",
+			"192.0.2.12": "www2.example.com",
+		},
+		failures: map[string]bool{"192.0.2.14": true},
+	}
+	current := map[string]string{
+		"192.0.2.10": "old.example.com",
+		"192.0.2.12": "www2.example.com",
+	}
+	previous := map[string]string{
+		"192.0.2.10": "old.example.com",
+		"192.0.2.12": "www2.example.com",
+	}
+	desired := map[string]string{
+		"192.0.2.11": "www1.example.com.",
+		"192.0.2.12": "www2.example.com",
+		"192.0.2.13": "www3.example.com",
+		"192.0.2.14": "www1.example.com",
+	}
+
+	diags := applyInternetPtrRecords(context.Background(), op, "is1a", current, previous, desired, true)
+
+	assert.Equal(t, map[string]string{
+		"192.0.2.10": "",
+		"192.0.2.11": "www1.example.com.",
+		"192.0.2.12": "www2.example.com",
+	}, op.hostnames)
+	assert.Len(t, diags, 2)
+	for _, d := range diags {
+		assert.Equal(t, diag.Warning, d.Severity)
+	}
+	assert.Contains(t, diags[0].Summary, "192.0.2.13")
+	assert.Contains(t, diags[1].Summary, "192.0.2.14")
+}
+
+func TestInternetPtrRecords_validateInternetPtrRecords(t *testing.T) {
+	addresses := []string{"192.0.2.10", "192.0.2.11"}
+
+	assert.NoError(t, validateInternetPtrRecords(map[string]string{"192.0.2.10": "www.example.com"}, addresses))
+	assert.Error(t, validateInternetPtrRecords(map[string]string{"192.0.2.20": "www.example.com"}, addresses))
+}
+
+func TestInternetPtrRecords_flattenInternetPtrRecords(t *testing.T) {
+	current := map[string]string{
+		"192.0.2.10": "www1.example.com",
+		"192.0.2.11": "",
+		"192.0.2.12": "www2.example.com",
+	}
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"ip_address": "192.0.2.10", "hostname": "www1.example.com"},
+	}, flattenInternetPtrRecords([]string{"192.0.2.10", "192.0.2.11"}, current))
+}
+
+var testAccSakuraCloudInternetPtrRecords_basic = `
+data sakuracloud_dns "dns" {
+  filter {
+    names = ["{{ .arg1 }}"]
+  }
+}
+
+resource "sakuracloud_internet" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource sakuracloud_dns_record "record01" {
+  dns_id = data.sakuracloud_dns.dns.id
+  name   = "terraform-test-ptr01"
+  type   = "A"
+  value  = sakuracloud_internet.foobar.ip_addresses[0]
+  ttl    = 10
+}
+
+resource sakuracloud_dns_record "record02" {
+  dns_id = data.sakuracloud_dns.dns.id
+  name   = "terraform-test-ptr02"
+  type   = "A"
+  value  = sakuracloud_internet.foobar.ip_addresses[1]
+  ttl    = 10
+}
+
+resource "sakuracloud_internet_ptr_records" "foobar" {
+  internet_id = sakuracloud_internet.foobar.id
+
+  record {
+    ip_address = sakuracloud_dns_record.record01.value
+    hostname   = "terraform-test-ptr01.{{ .arg1 }}"
+  }
+  record {
+    ip_address = sakuracloud_dns_record.record02.value
+    hostname   = "terraform-test-ptr02.{{ .arg1 }}"
+  }
+}
+`
+
+var testAccSakuraCloudInternetPtrRecords_update = `
+data sakuracloud_dns "dns" {
+  filter {
+    names = ["{{ .arg1 }}"]
+  }
+}
+
+resource "sakuracloud_internet" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource sakuracloud_dns_record "record01" {
+  dns_id = data.sakuracloud_dns.dns.id
+  name   = "terraform-test-ptr01"
+  type   = "A"
+  value  = sakuracloud_internet.foobar.ip_addresses[0]
+  ttl    = 10
+}
+
+resource "sakuracloud_internet_ptr_records" "foobar" {
+  internet_id = sakuracloud_internet.foobar.id
+
+  record {
+    ip_address = sakuracloud_dns_record.record01.value
+    hostname   = "terraform-test-ptr01.{{ .arg1 }}"
+  }
+}
+`
@@ -51,6 +51,8 @@ func TestAccSakuraCloudInternet_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.1", "tag2"),
 					resource.TestCheckResourceAttr(resourceName, "netmask", "28"),
 					resource.TestCheckResourceAttr(resourceName, "band_width", "100"),
+					resource.TestCheckResourceAttrPair(resourceName, "router_id", resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "router_id_history.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "server_ids.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "ip_addresses.#", "11"),
 					resource.TestCheckResourceAttrPair(
@@ -70,6 +72,7 @@ func TestAccSakuraCloudInternet_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.1", "tag2-upd"),
 					resource.TestCheckResourceAttr(resourceName, "netmask", "28"),
 					resource.TestCheckResourceAttr(resourceName, "band_width", "500"),
+					resource.TestCheckResourceAttrSet(resourceName, "router_id"),
 					resource.TestCheckResourceAttr(resourceName, "server_ids.#", "0"),
 					resource.TestCheckResourceAttr(resourceName, "ip_addresses.#", "11"),
 					resource.TestCheckResourceAttr(resourceName, "enable_ipv6", "true"),
@@ -97,12 +100,13 @@ func testCheckSakuraCloudInternetExists(n string, internet *sacloud.Internet) re
 		internetOp := sacloud.NewInternetOp(client)
 		zone := rs.Primary.Attributes["zone"]
 
-		foundInternet, err := internetOp.Read(context.Background(), zone, sakuraCloudID(rs.Primary.ID))
+		routerID := rs.Primary.Attributes["router_id"]
+		foundInternet, err := internetOp.Read(context.Background(), zone, sakuraCloudID(routerID))
 		if err != nil {
 			return err
 		}
 
-		if foundInternet.ID.String() != rs.Primary.ID {
+		if foundInternet.ID.String() != routerID {
 			return fmt.Errorf("not found Internet: %s", rs.Primary.ID)
 		}
 
@@ -124,7 +128,7 @@ func testCheckSakuraCloudInternetDestroy(s *terraform.State) error {
 		}
 
 		zone := rs.Primary.Attributes["zone"]
-		_, err := internetOp.Read(context.Background(), zone, sakuraCloudID(rs.Primary.ID))
+		_, err := internetOp.Read(context.Background(), zone, sakuraCloudID(rs.Primary.Attributes["router_id"]))
 		if err == nil {
 			return fmt.Errorf("still exists Internet: %s", rs.Primary.ID)
 		}
@@ -0,0 +1,459 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func resourceSakuraCloudIPAddressAllocation() *schema.Resource {
+	resourceName := "IP Address Allocation"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudIPAddressAllocationCreate,
+		ReadContext:   resourceSakuraCloudIPAddressAllocationRead,
+		UpdateContext: resourceSakuraCloudIPAddressAllocationUpdate,
+		DeleteContext: resourceSakuraCloudIPAddressAllocationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSakuraCloudIPAddressAllocationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"switch_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"switch_id", "internet_id", "subnet_id"},
+				RequiredWith:     []string{"cidr"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Switch to allocate the IP address from. `cidr` is required when this is specified",
+			},
+			"internet_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"switch_id", "internet_id", "subnet_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Internet(Switch+Router) to allocate the IP address from",
+			},
+			"subnet_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"switch_id", "internet_id", "subnet_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Subnet to allocate the IP address from",
+			},
+			"cidr": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"internet_id", "subnet_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsCIDR),
+				Description:      "The CIDR block to allocate the IP address from (e.g. `192.168.0.0/24`). The mask length must be `16` or more",
+			},
+			"exclude_ip_addresses": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of IP address not to be allocated. This is only used when allocating the IP address",
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The allocated IP address",
+			},
+			"netmask": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The bit length of the subnet of the allocated IP address",
+			},
+			"gateway": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address of the gateway of the subnet",
+			},
+			"zone": schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudIPAddressAllocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	source, err := expandIPAddressAllocationSource(ctx, d, client, zone)
+	if err != nil {
+		return diag.Errorf("allocating IP address is failed: %s", err)
+	}
+
+	sakuraMutexKV.Lock(source.switchID.String())
+	defer sakuraMutexKV.Unlock(source.switchID.String())
+
+	sw, err := sacloud.NewSwitchOp(client).Read(ctx, zone, source.switchID)
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Switch[%s]: %s", source.switchID, err)
+	}
+	interfaces, err := findSwitchTopologyInterfaces(ctx, client, zone, sw)
+	if err != nil {
+		return diag.Errorf("allocating IP address is failed: %s", err)
+	}
+
+	used := expandIPAddressAllocationUsed(source, interfaces, expandStringList(d.Get("exclude_ip_addresses").([]interface{})))
+	ip, err := allocateIPAddress(source.candidates, used)
+	if err != nil {
+		return diag.Errorf("allocating IP address from Switch[%s] is failed: %s", source.switchID, err)
+	}
+	ipAddressAllocations.add(source.switchID, ip)
+
+	d.SetId(ipAddressAllocationID(source.switchID, ip))
+	d.Set("cidr", source.cidr)       // nolint
+	d.Set("netmask", source.netmask) // nolint
+	d.Set("gateway", source.gateway) // nolint
+	return resourceSakuraCloudIPAddressAllocationRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudIPAddressAllocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	swID, ip, err := parseIPAddressAllocationID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := sacloud.NewSwitchOp(client).Read(ctx, zone, swID); err != nil {
+		if sacloud.IsNotFoundError(err) {
+			ipAddressAllocations.remove(swID, ip)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud Switch[%s]: %s", swID, err)
+	}
+
+	// 割り当て済みのIPアドレスはstateに保持されたものをそのまま使い、以降の割り当てから除外する
+	ipAddressAllocations.add(swID, ip)
+
+	d.Set("switch_id", swID.String()) // nolint
+	d.Set("ip_address", ip)           // nolint
+	d.Set("zone", getZone(d, client)) // nolint
+	return nil
+}
+
+func resourceSakuraCloudIPAddressAllocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceSakuraCloudIPAddressAllocationRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudIPAddressAllocationDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	swID, ip, err := parseIPAddressAllocationID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sakuraMutexKV.Lock(swID.String())
+	defer sakuraMutexKV.Unlock(swID.String())
+
+	ipAddressAllocations.remove(swID, ip)
+	d.SetId("")
+	return nil
+}
+
+// resourceSakuraCloudIPAddressAllocationImport "[<zone>/]<switch_id>/<ip>[/<mask_len>]"形式のIDでのインポートを行う
+//
+// 割り当て元のCIDRブロックはスイッチのサブネットから導出する。
+// スイッチ+ルータやサブネットに含まれないIPアドレスの場合、マスク長を省略するとスイッチに設定されたマスク長を用いる
+func resourceSakuraCloudIPAddressAllocationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*APIClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) > 0 && sakuraCloudID(parts[0]).IsEmpty() {
+		if err := client.validateZone("zone", parts[0]); err != nil {
+			return nil, err
+		}
+		d.Set("zone", parts[0]) // nolint
+		parts = parts[1:]
+	}
+	maskLen := 0
+	if len(parts) == 3 {
+		n, err := strconv.Atoi(parts[2])
+		if err != nil || n < 16 || n > 32 {
+			return nil, fmt.Errorf("invalid mask length %q: the mask length must be in the range [16-32]", parts[2])
+		}
+		maskLen = n
+		parts = parts[:2]
+	}
+	swID, ip, err := parseIPAddressAllocationID(strings.Join(parts, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	zone := getZone(d, client)
+	sw, err := sacloud.NewSwitchOp(client).Read(ctx, zone, swID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read SakuraCloud Switch[%s]: %s", swID, err)
+	}
+	if err := setIPAddressAllocationImportSource(d, sw, ip, maskLen); err != nil {
+		return nil, err
+	}
+	d.SetId(ipAddressAllocationID(swID, ip))
+
+	if diags := resourceSakuraCloudIPAddressAllocationRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("could not read IP address allocation[%s]: %s", d.Id(), diags[0].Summary)
+	}
+	if d.Id() == "" {
+		return nil, errors.New("IP address allocation is not found")
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// setIPAddressAllocationImportSource インポートするIPアドレスを含むスイッチのサブネットから割り当て元の情報を設定する
+func setIPAddressAllocationImportSource(d *schema.ResourceData, sw *sacloud.Switch, ip string, maskLen int) error {
+	for i, subnet := range sw.Subnets {
+		cidr := fmt.Sprintf("%s/%d", subnet.NetworkAddress, subnet.NetworkMaskLen)
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil || !ipNet.Contains(net.ParseIP(ip)) {
+			continue
+		}
+		// 先頭のサブネットはスイッチ+ルータ自身のもの、以降は追加されたサブネット
+		if i == 0 && subnet.Internet != nil {
+			d.Set("internet_id", subnet.Internet.ID.String()) // nolint
+		} else {
+			d.Set("subnet_id", subnet.ID.String()) // nolint
+		}
+		d.Set("cidr", cidr)                     // nolint
+		d.Set("netmask", subnet.NetworkMaskLen) // nolint
+		d.Set("gateway", subnet.DefaultRoute)   // nolint
+		return nil
+	}
+
+	if maskLen == 0 {
+		maskLen = sw.NetworkMaskLen
+	}
+	if maskLen == 0 {
+		return fmt.Errorf("Switch[%s] has no subnet: specify the mask length of the CIDR block in the form of <switch_id>/<ip>/<mask_len>", sw.ID)
+	}
+	_, ipNet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", ip, maskLen))
+	if err != nil {
+		return err
+	}
+	d.Set("cidr", ipNet.String())     // nolint
+	d.Set("netmask", maskLen)         // nolint
+	d.Set("gateway", sw.DefaultRoute) // nolint
+	return nil
+}
+
+// ipAddressAllocationSource IPアドレスの割り当て元
+type ipAddressAllocationSource struct {
+	switchID   types.ID
+	cidr       string
+	candidates []string
+	netmask    int
+	gateway    string
+}
+
+func expandIPAddressAllocationSource(ctx context.Context, d resourceValueGettable, client *APIClient, zone string) (*ipAddressAllocationSource, error) {
+	swOp := sacloud.NewSwitchOp(client)
+
+	switch {
+	case d.Get("internet_id").(string) != "":
+		internetID := expandSakuraCloudID(d, "internet_id")
+		internet, err := readInternet(ctx, client, zone, internetID)
+		if err != nil {
+			return nil, fmt.Errorf("could not read SakuraCloud Internet[%s]: %s", internetID, err)
+		}
+		sw, err := swOp.Read(ctx, zone, internet.Switch.ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not read SakuraCloud Switch[%s]: %s", internet.Switch.ID, err)
+		}
+		if len(sw.Subnets) == 0 {
+			return nil, fmt.Errorf("Switch[%s] of Internet[%s] has no subnet", sw.ID, internetID)
+		}
+		subnet := sw.Subnets[0]
+		return &ipAddressAllocationSource{
+			switchID:   sw.ID,
+			cidr:       fmt.Sprintf("%s/%d", subnet.NetworkAddress, subnet.NetworkMaskLen),
+			candidates: subnet.GetAssignedIPAddresses(),
+			netmask:    subnet.NetworkMaskLen,
+			gateway:    subnet.DefaultRoute,
+		}, nil
+	case d.Get("subnet_id").(string) != "":
+		subnetID := expandSakuraCloudID(d, "subnet_id")
+		subnet, err := sacloud.NewSubnetOp(client).Read(ctx, zone, subnetID)
+		if err != nil {
+			return nil, fmt.Errorf("could not read SakuraCloud Subnet[%s]: %s", subnetID, err)
+		}
+		var candidates []string
+		for _, ip := range subnet.IPAddresses {
+			candidates = append(candidates, ip.IPAddress)
+		}
+		return &ipAddressAllocationSource{
+			switchID:   subnet.SwitchID,
+			cidr:       fmt.Sprintf("%s/%d", subnet.NetworkAddress, subnet.NetworkMaskLen),
+			candidates: candidates,
+			netmask:    subnet.NetworkMaskLen,
+			gateway:    subnet.DefaultRoute,
+		}, nil
+	default:
+		swID := expandSakuraCloudID(d, "switch_id")
+		sw, err := swOp.Read(ctx, zone, swID)
+		if err != nil {
+			return nil, fmt.Errorf("could not read SakuraCloud Switch[%s]: %s", swID, err)
+		}
+		cidr := d.Get("cidr").(string)
+		candidates, netmask, err := expandIPAddressAllocationCandidates(cidr)
+		if err != nil {
+			return nil, err
+		}
+		return &ipAddressAllocationSource{
+			switchID:   sw.ID,
+			cidr:       cidr,
+			candidates: candidates,
+			netmask:    netmask,
+			gateway:    sw.DefaultRoute,
+		}, nil
+	}
+}
+
+// expandIPAddressAllocationCandidates CIDRブロックからネットワークアドレスとブロードキャストアドレスを除いたIPアドレスのリストを返す
+func expandIPAddressAllocationCandidates(cidr string) ([]string, int, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, 0, err
+	}
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return nil, 0, fmt.Errorf("cidr %q is not an IPv4 CIDR block", cidr)
+	}
+	maskLen, _ := ipNet.Mask.Size()
+	if maskLen < 16 {
+		return nil, 0, fmt.Errorf("cidr %q is too large: the mask length must be 16 or more", cidr)
+	}
+
+	from := binary.BigEndian.Uint32(ip)
+	to := from | (uint32(1<<uint(32-maskLen)) - 1)
+	if maskLen < 31 {
+		from++
+		to--
+	}
+
+	var candidates []string
+	for i := from; i <= to; i++ {
+		addr := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(addr, i)
+		candidates = append(candidates, addr.String())
+	}
+	return candidates, maskLen, nil
+}
+
+// allocateIPAddress candidatesのうち、usedに含まれない先頭のIPアドレスを返す
+func allocateIPAddress(candidates []string, used []string) (string, error) {
+	usedMap := make(map[string]bool)
+	for _, ip := range used {
+		usedMap[ip] = true
+	}
+	for _, ip := range candidates {
+		if !usedMap[ip] {
+			return ip, nil
+		}
+	}
+	return "", errors.New("no free IP address")
+}
+
+// ipAddressAllocationRegistry プロバイダ内で割り当て済みのIPアドレスをスイッチごとに保持する
+//
+// 作成時とリフレッシュ時に記録し、削除時に取り除く。
+// 同一のapply内で並行して割り当てを行った場合や、state上の他の割り当てと同じIPアドレスが割り当てられないようにするために利用する
+type ipAddressAllocationRegistry struct {
+	mu        sync.Mutex
+	allocated map[types.ID]map[string]bool
+}
+
+var ipAddressAllocations = &ipAddressAllocationRegistry{allocated: make(map[types.ID]map[string]bool)}
+
+func (r *ipAddressAllocationRegistry) add(switchID types.ID, ip string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.allocated[switchID] == nil {
+		r.allocated[switchID] = make(map[string]bool)
+	}
+	r.allocated[switchID][ip] = true
+}
+
+func (r *ipAddressAllocationRegistry) remove(switchID types.ID, ip string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.allocated[switchID], ip)
+}
+
+func (r *ipAddressAllocationRegistry) list(switchID types.ID) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ips []string
+	for ip := range r.allocated[switchID] {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+// expandIPAddressAllocationUsed 割り当て済みとして扱うIPアドレスのリストを返す
+//
+// スイッチに接続されたリソースのIPアドレス、ゲートウェイ、他の割り当て、除外指定されたIPアドレスを含む
+func expandIPAddressAllocationUsed(source *ipAddressAllocationSource, interfaces []interface{}, excludes []string) []string {
+	used := ipAddressAllocations.list(source.switchID)
+	for _, iface := range interfaces {
+		used = append(used, iface.(map[string]interface{})["ip_addresses"].([]string)...)
+	}
+	if source.gateway != "" {
+		used = append(used, source.gateway)
+	}
+	return append(used, excludes...)
+}
+
+func ipAddressAllocationID(switchID types.ID, ip string) string {
+	return fmt.Sprintf("%s/%s", switchID, ip)
+}
+
+// parseIPAddressAllocationID "<switch_id>/<ip>"形式のIDをスイッチのIDとIPアドレスに分割する
+func parseIPAddressAllocationID(id string) (types.ID, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || sakuraCloudID(parts[0]).IsEmpty() || net.ParseIP(parts[1]).To4() == nil {
+		return types.ID(0), "", fmt.Errorf("invalid IP address allocation id %q: the id must be in the form of <switch_id>/<ip>", id)
+	}
+	return sakuraCloudID(parts[0]), parts[1], nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSakuraCloudIPAddressAllocation_basic(t *testing.T) {
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudIPAddressAllocationDestroy,
+			testCheckSakuraCloudServerDestroy,
+			testCheckSakuraCloudSwitchDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudIPAddressAllocation_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"sakuracloud_ip_address_allocation.foobar1", "switch_id",
+						"sakuracloud_switch.foobar", "id",
+					),
+					resource.TestCheckResourceAttr("sakuracloud_ip_address_allocation.foobar1", "netmask", "28"),
+					resource.TestCheckResourceAttr("sakuracloud_ip_address_allocation.foobar1", "cidr", "192.168.0.0/28"),
+					resource.TestCheckResourceAttr("sakuracloud_ip_address_allocation.foobar1", "ip_address", "192.168.0.3"),
+					resource.TestCheckResourceAttr("sakuracloud_ip_address_allocation.foobar2", "ip_address", "192.168.0.4"),
+				),
+			},
+			{
+				// 割り当て済みのIPアドレスは変化しないこと
+				Config: buildConfigWithArgs(testAccSakuraCloudIPAddressAllocation_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sakuracloud_ip_address_allocation.foobar1", "ip_address", "192.168.0.3"),
+					resource.TestCheckResourceAttr("sakuracloud_ip_address_allocation.foobar2", "ip_address", "192.168.0.4"),
+				),
+			},
+			{
+				ResourceName: "sakuracloud_ip_address_allocation.foobar1",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["sakuracloud_ip_address_allocation.foobar1"]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", "sakuracloud_ip_address_allocation.foobar1")
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.ID, rs.Primary.Attributes["netmask"]), nil
+				},
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"exclude_ip_addresses",
+				},
+			},
+			{
+				ResourceName: "sakuracloud_ip_address_allocation.foobar1",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["sakuracloud_ip_address_allocation.foobar1"]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", "sakuracloud_ip_address_allocation.foobar1")
+					}
+					return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["zone"], rs.Primary.ID, rs.Primary.Attributes["netmask"]), nil
+				},
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"exclude_ip_addresses",
+				},
+			},
+		},
+	})
+}
+
+func testCheckSakuraCloudIPAddressAllocationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sakuracloud_ip_address_allocation" {
+			continue
+		}
+		if rs.Primary.ID == "" {
+			continue
+		}
+
+		swID, ip, err := parseIPAddressAllocationID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		for _, allocated := range ipAddressAllocations.list(swID) {
+			if allocated == ip {
+				return fmt.Errorf("still exists IP address allocation: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+var testAccSakuraCloudIPAddressAllocation_basic = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "{{ .arg0 }}"
+  network_interface {
+    upstream        = sakuracloud_switch.foobar.id
+    user_ip_address = "192.168.0.1"
+  }
+  force_shutdown = true
+}
+
+resource "sakuracloud_ip_address_allocation" "foobar1" {
+  switch_id            = sakuracloud_switch.foobar.id
+  cidr                 = "192.168.0.0/28"
+  exclude_ip_addresses = ["192.168.0.2"]
+
+  depends_on = [sakuracloud_server.foobar]
+}
+
+resource "sakuracloud_ip_address_allocation" "foobar2" {
+  switch_id            = sakuracloud_switch.foobar.id
+  cidr                 = "192.168.0.0/28"
+  exclude_ip_addresses = ["192.168.0.2"]
+
+  depends_on = [sakuracloud_ip_address_allocation.foobar1]
+}
+`
+
+func TestResourceIPAddressAllocation_expandIPAddressAllocationCandidates(t *testing.T) {
+	candidates, netmask, err := expandIPAddressAllocationCandidates("192.168.0.0/29")
+	assert.NoError(t, err)
+	assert.Equal(t, 29, netmask)
+	assert.Equal(t, []string{
+		"192.168.0.1", "192.168.0.2", "192.168.0.3", "192.168.0.4", "192.168.0.5", "192.168.0.6",
+	}, candidates)
+
+	candidates, _, err = expandIPAddressAllocationCandidates("192.168.0.8/31")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.0.8", "192.168.0.9"}, candidates)
+
+	_, _, err = expandIPAddressAllocationCandidates("10.0.0.0/8")
+	assert.Error(t, err)
+	_, _, err = expandIPAddressAllocationCandidates("2001:db8::/64")
+	assert.Error(t, err)
+}
+
+func TestResourceIPAddressAllocation_allocateIPAddress(t *testing.T) {
+	candidates := []string{"192.168.0.1", "192.168.0.2", "192.168.0.3"}
+
+	ip, err := allocateIPAddress(candidates, []string{"192.168.0.1", "192.168.0.10"})
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.2", ip)
+
+	_, err = allocateIPAddress(candidates, candidates)
+	assert.Error(t, err)
+}
+
+func TestResourceIPAddressAllocation_parseIPAddressAllocationID(t *testing.T) {
+	swID, ip, err := parseIPAddressAllocationID("100000000001/192.168.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ID(100000000001), swID)
+	assert.Equal(t, "192.168.0.1", ip)
+	assert.Equal(t, "100000000001/192.168.0.1", ipAddressAllocationID(swID, ip))
+
+	for _, id := range []string{
+		"192.168.0.1",
+		"is1a/100000000001/192.168.0.1",
+		"100000000001/",
+		"foobar/192.168.0.1",
+		"100000000001/2001:db8::1",
+	} {
+		_, _, err := parseIPAddressAllocationID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestResourceIPAddressAllocation_expandIPAddressAllocationUsed(t *testing.T) {
+	candidates, netmask, err := expandIPAddressAllocationCandidates("192.168.0.0/29")
+	assert.NoError(t, err)
+	source := &ipAddressAllocationSource{
+		switchID:   types.ID(100000000099),
+		cidr:       "192.168.0.0/29",
+		candidates: candidates,
+		netmask:    netmask,
+		gateway:    "192.168.0.1",
+	}
+	interfaces := []interface{}{
+		map[string]interface{}{"ip_addresses": []string{"192.168.0.2"}},
+	}
+
+	// ゲートウェイがCIDRブロックに含まれる場合、ゲートウェイのIPアドレスは割り当てないこと
+	used := expandIPAddressAllocationUsed(source, interfaces, []string{"192.168.0.4"})
+	assert.Equal(t, []string{"192.168.0.2", "192.168.0.1", "192.168.0.4"}, used)
+	ip, err := allocateIPAddress(source.candidates, used)
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.3", ip)
+
+	// 他の割り当てで記録済みのIPアドレスも割り当てないこと
+	ipAddressAllocations.add(source.switchID, ip)
+	defer ipAddressAllocations.remove(source.switchID, ip)
+	ip, err = allocateIPAddress(source.candidates, expandIPAddressAllocationUsed(source, interfaces, []string{"192.168.0.4"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.0.5", ip)
+}
+
+func TestResourceIPAddressAllocation_setIPAddressAllocationImportSource(t *testing.T) {
+	sw := &sacloud.Switch{
+		ID: types.ID(100000000001),
+		Subnets: []*sacloud.SwitchSubnet{
+			{
+				ID:             types.ID(100000000002),
+				NetworkAddress: "192.0.2.0",
+				NetworkMaskLen: 28,
+				DefaultRoute:   "192.0.2.1",
+				Internet:       &sacloud.Internet{ID: types.ID(100000000003)},
+			},
+			{
+				ID:             types.ID(100000000004),
+				NetworkAddress: "198.51.100.0",
+				NetworkMaskLen: 28,
+				NextHop:        "192.0.2.4",
+			},
+		},
+	}
+	resourceSchema := resourceSakuraCloudIPAddressAllocation().Schema
+
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	assert.NoError(t, setIPAddressAllocationImportSource(d, sw, "192.0.2.5", 0))
+	assert.Equal(t, "100000000003", d.Get("internet_id"))
+	assert.Equal(t, "192.0.2.0/28", d.Get("cidr"))
+	assert.Equal(t, 28, d.Get("netmask"))
+	assert.Equal(t, "192.0.2.1", d.Get("gateway"))
+
+	d = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	assert.NoError(t, setIPAddressAllocationImportSource(d, sw, "198.51.100.5", 0))
+	assert.Equal(t, "100000000004", d.Get("subnet_id"))
+	assert.Equal(t, "198.51.100.0/28", d.Get("cidr"))
+
+	d = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	assert.NoError(t, setIPAddressAllocationImportSource(d, sw, "192.168.0.3", 28))
+	assert.Equal(t, "192.168.0.0/28", d.Get("cidr"))
+	assert.Equal(t, 28, d.Get("netmask"))
+
+	d = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	assert.Error(t, setIPAddressAllocationImportSource(d, sw, "192.168.0.3", 0))
+}
@@ -36,8 +36,9 @@ func resourceSakuraCloudLoadBalancer() *schema.Resource {
 		ReadContext:   resourceSakuraCloudLoadBalancerRead,
 		UpdateContext: resourceSakuraCloudLoadBalancerUpdate,
 		DeleteContext: resourceSakuraCloudLoadBalancerDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudLoadBalancerRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -94,6 +95,7 @@ func resourceSakuraCloudLoadBalancer() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 			"vip": {
 				Type:     schema.TypeList,
@@ -187,7 +189,7 @@ func resourceSakuraCloudLoadBalancerCreate(ctx context.Context, d *schema.Resour
 
 	builder := &setup.RetryableSetup{
 		Create: func(ctx context.Context, zone string) (accessor.ID, error) {
-			return lbOp.Create(ctx, zone, expandLoadBalancerCreateRequest(d))
+			return lbOp.Create(ctx, zone, expandLoadBalancerCreateRequest(d, client))
 		},
 		ProvisionBeforeUp: func(ctx context.Context, zone string, id types.ID, _ interface{}) error {
 			return lbOp.Config(ctx, zone, id)
@@ -247,7 +249,7 @@ func resourceSakuraCloudLoadBalancerUpdate(ctx context.Context, d *schema.Resour
 		return diag.Errorf("could not read SakuraCloud LoadBalancer[%s]: %s", d.Id(), err)
 	}
 
-	if _, err := lbOp.Update(ctx, zone, lb.ID, expandLoadBalancerUpdateRequest(d, lb)); err != nil {
+	if _, err := lbOp.Update(ctx, zone, lb.ID, expandLoadBalancerUpdateRequest(d, client, lb)); err != nil {
 		return diag.Errorf("updating SakuraCloud LoadBalancer[%s] is failed: %s", d.Id(), err)
 	}
 	if err := lbOp.Config(ctx, zone, lb.ID); err != nil {
@@ -303,5 +305,5 @@ func setLoadBalancerResourceData(ctx context.Context, d *schema.ResourceData, cl
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
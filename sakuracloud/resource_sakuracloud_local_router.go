@@ -32,8 +32,9 @@ func resourceSakuraCloudLocalRouter() *schema.Resource {
 		ReadContext:   resourceSakuraCloudLocalRouterRead,
 		UpdateContext: resourceSakuraCloudLocalRouterUpdate,
 		DeleteContext: resourceSakuraCloudLocalRouterDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudLocalRouterRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -157,6 +158,7 @@ func resourceSakuraCloudLocalRouter() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"secret_keys": {
 				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeString},
@@ -285,5 +287,5 @@ func setLocalRouterResourceData(ctx context.Context, d *schema.ResourceData, cli
 	if err := d.Set("static_route", flattenLocalRouterStaticRoutes(data)); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
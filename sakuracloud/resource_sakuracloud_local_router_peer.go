@@ -0,0 +1,378 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func resourceSakuraCloudLocalRouterPeer() *schema.Resource {
+	resourceName := "LocalRouter Peer"
+
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudLocalRouterPeerCreate,
+		ReadContext:   resourceSakuraCloudLocalRouterPeerRead,
+		UpdateContext: resourceSakuraCloudLocalRouterPeerUpdate,
+		DeleteContext: resourceSakuraCloudLocalRouterPeerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSakuraCloudLocalRouterPeerImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"local_router_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the LocalRouter",
+			},
+			"peer_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the peer LocalRouter",
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "The secret key of the peer LocalRouter. If this is omitted, both of the LocalRouters must be readable and the peering is set up on both sides",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "The flag to enable the peering",
+			},
+			"description": schemaResourceDescription(resourceName),
+			"bidirectional": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The flag whether the peering is set up on both sides by this resource",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The health status of the peer. This will be empty if the status is not available",
+			},
+			"routes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of the CIDR blocks advertised from the peer",
+			},
+		},
+	}
+}
+
+func resourceSakuraCloudLocalRouterPeerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lrOp := sacloud.NewLocalRouterOp(client)
+	localRouterID := expandSakuraCloudID(d, "local_router_id")
+	peerID := expandSakuraCloudID(d, "peer_id")
+	if localRouterID == peerID {
+		return diag.Errorf("creating SakuraCloud LocalRouter Peer is failed: peer_id must be different from local_router_id")
+	}
+
+	secretKey := d.Get("secret_key").(string)
+	bidirectional := secretKey == ""
+
+	localRouter, err := lrOp.Read(ctx, localRouterID)
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud LocalRouter[%s]: %s", localRouterID, err)
+	}
+	if peer := findLocalRouterPeer(localRouter, peerID); peer != nil {
+		return diag.Errorf("creating SakuraCloud LocalRouter Peer is failed: LocalRouter[%s] is already peered with LocalRouter[%s]", localRouterID, peerID)
+	}
+
+	var localSecretKey string
+	if bidirectional {
+		peerRouter, err := lrOp.Read(ctx, peerID)
+		if err != nil {
+			return diag.Errorf("could not read SakuraCloud LocalRouter[%s]: secret_key is required when the peer LocalRouter is not readable: %s", peerID, err)
+		}
+		if len(peerRouter.SecretKeys) == 0 || len(localRouter.SecretKeys) == 0 {
+			return diag.Errorf("creating SakuraCloud LocalRouter Peer is failed: secret keys of LocalRouter[%s] and LocalRouter[%s] are not available", localRouterID, peerID)
+		}
+		secretKey = peerRouter.SecretKeys[0]
+		localSecretKey = localRouter.SecretKeys[0]
+	}
+
+	enabled := d.Get("enabled").(bool)
+	description := d.Get("description").(string)
+
+	if err := updateLocalRouterPeer(ctx, lrOp, localRouterID, &sacloud.LocalRouterPeer{
+		ID:          peerID,
+		SecretKey:   secretKey,
+		Enabled:     enabled,
+		Description: description,
+	}); err != nil {
+		return diag.Errorf("creating SakuraCloud LocalRouter Peer is failed: %s", err)
+	}
+	d.SetId(localRouterPeerID(localRouterID, peerID))
+	d.Set("bidirectional", bidirectional) // nolint
+
+	if bidirectional {
+		if err := updateLocalRouterPeer(ctx, lrOp, peerID, &sacloud.LocalRouterPeer{
+			ID:          localRouterID,
+			SecretKey:   localSecretKey,
+			Enabled:     enabled,
+			Description: description,
+		}); err != nil {
+			return diag.Errorf("creating SakuraCloud LocalRouter Peer on LocalRouter[%s] is failed: %s", peerID, err)
+		}
+	}
+
+	return resourceSakuraCloudLocalRouterPeerRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudLocalRouterPeerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lrOp := sacloud.NewLocalRouterOp(client)
+	localRouterID := expandSakuraCloudID(d, "local_router_id")
+	peerID := expandSakuraCloudID(d, "peer_id")
+
+	localRouter, err := lrOp.Read(ctx, localRouterID)
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud LocalRouter[%s]: %s", localRouterID, err)
+	}
+
+	peer := findLocalRouterPeer(localRouter, peerID)
+	if peer == nil {
+		d.SetId("")
+		return nil
+	}
+
+	health, err := lrOp.HealthStatus(ctx, localRouterID)
+	if err != nil && !sacloud.IsNotFoundError(err) {
+		return diag.Errorf("could not read health status of SakuraCloud LocalRouter[%s]: %s", localRouterID, err)
+	}
+	status, routes := flattenLocalRouterPeerHealth(health, peerID)
+
+	d.Set("enabled", peer.Enabled)         // nolint
+	d.Set("description", peer.Description) // nolint
+	d.Set("status", status)                // nolint
+	if !d.Get("bidirectional").(bool) {
+		d.Set("secret_key", peer.SecretKey) // nolint
+	}
+	return diag.FromErr(d.Set("routes", routes))
+}
+
+func resourceSakuraCloudLocalRouterPeerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lrOp := sacloud.NewLocalRouterOp(client)
+	localRouterID := expandSakuraCloudID(d, "local_router_id")
+	peerID := expandSakuraCloudID(d, "peer_id")
+	enabled := d.Get("enabled").(bool)
+	description := d.Get("description").(string)
+
+	targets := []struct{ routerID, peerID types.ID }{{localRouterID, peerID}}
+	if d.Get("bidirectional").(bool) {
+		targets = append(targets, struct{ routerID, peerID types.ID }{peerID, localRouterID})
+	}
+
+	for _, target := range targets {
+		localRouter, err := lrOp.Read(ctx, target.routerID)
+		if err != nil {
+			return diag.Errorf("could not read SakuraCloud LocalRouter[%s]: %s", target.routerID, err)
+		}
+		peer := findLocalRouterPeer(localRouter, target.peerID)
+		if peer == nil {
+			return diag.Errorf("updating SakuraCloud LocalRouter Peer[%s] is failed: LocalRouter[%s] is not peered with LocalRouter[%s]", d.Id(), target.routerID, target.peerID)
+		}
+		if err := updateLocalRouterPeer(ctx, lrOp, target.routerID, &sacloud.LocalRouterPeer{
+			ID:          target.peerID,
+			SecretKey:   peer.SecretKey,
+			Enabled:     enabled,
+			Description: description,
+		}); err != nil {
+			return diag.Errorf("updating SakuraCloud LocalRouter Peer[%s] is failed: %s", d.Id(), err)
+		}
+	}
+
+	return resourceSakuraCloudLocalRouterPeerRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudLocalRouterPeerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	lrOp := sacloud.NewLocalRouterOp(client)
+	localRouterID := expandSakuraCloudID(d, "local_router_id")
+	peerID := expandSakuraCloudID(d, "peer_id")
+
+	if err := deleteLocalRouterPeer(ctx, lrOp, localRouterID, peerID); err != nil {
+		return diag.Errorf("deleting SakuraCloud LocalRouter Peer[%s] is failed: %s", d.Id(), err)
+	}
+	if d.Get("bidirectional").(bool) {
+		if err := deleteLocalRouterPeer(ctx, lrOp, peerID, localRouterID); err != nil {
+			return diag.Errorf("deleting SakuraCloud LocalRouter Peer[%s] on LocalRouter[%s] is failed: %s", d.Id(), peerID, err)
+		}
+	}
+	return nil
+}
+
+// resourceSakuraCloudLocalRouterPeerImport <local_router_id>/<peer_id>形式のIDからピアリングをインポートする
+//
+// ピア側のローカルルータにも逆向きのピアリングが設定されている場合は両側を管理対象とする
+func resourceSakuraCloudLocalRouterPeerImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	localRouterID, peerID, err := expandLocalRouterPeerID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	client := meta.(*APIClient)
+	lrOp := sacloud.NewLocalRouterOp(client)
+
+	bidirectional := false
+	if peerRouter, err := lrOp.Read(ctx, peerID); err == nil {
+		bidirectional = findLocalRouterPeer(peerRouter, localRouterID) != nil
+	}
+
+	d.Set("local_router_id", localRouterID.String()) // nolint
+	d.Set("peer_id", peerID.String())                // nolint
+	d.Set("bidirectional", bidirectional)            // nolint
+	return []*schema.ResourceData{d}, nil
+}
+
+func localRouterPeerID(localRouterID, peerID types.ID) string {
+	return fmt.Sprintf("%s/%s", localRouterID, peerID)
+}
+
+func expandLocalRouterPeerID(id string) (types.ID, types.ID, error) {
+	values := strings.Split(id, "/")
+	if len(values) != 2 {
+		return types.ID(0), types.ID(0), fmt.Errorf("invalid ID: %q: ID must be in the format <local_router_id>/<peer_id>", id)
+	}
+	localRouterID, peerID := sakuraCloudID(values[0]), sakuraCloudID(values[1])
+	if localRouterID.IsEmpty() || peerID.IsEmpty() {
+		return types.ID(0), types.ID(0), fmt.Errorf("invalid ID: %q: ID must be in the format <local_router_id>/<peer_id>", id)
+	}
+	return localRouterID, peerID, nil
+}
+
+func findLocalRouterPeer(data *sacloud.LocalRouter, peerID types.ID) *sacloud.LocalRouterPeer {
+	for _, peer := range data.Peers {
+		if peer.ID == peerID {
+			return peer
+		}
+	}
+	return nil
+}
+
+func flattenLocalRouterPeerHealth(health *sacloud.LocalRouterHealth, peerID types.ID) (string, []string) {
+	if health == nil {
+		return "", nil
+	}
+	for _, peer := range health.Peers {
+		if peer.ID == peerID {
+			return string(peer.Status), peer.Routes
+		}
+	}
+	return "", nil
+}
+
+// updateLocalRouterPeer ローカルルータのピア設定を追加/更新する
+//
+// スイッチやインターフェースなど他の設定は維持したままUpdateSettingsを呼び出す
+func updateLocalRouterPeer(ctx context.Context, lrOp sacloud.LocalRouterAPI, id types.ID, peer *sacloud.LocalRouterPeer) error {
+	return updateLocalRouterPeers(ctx, lrOp, id, func(peers []*sacloud.LocalRouterPeer) []*sacloud.LocalRouterPeer {
+		var results []*sacloud.LocalRouterPeer
+		updated := false
+		for _, p := range peers {
+			if p.ID == peer.ID {
+				results = append(results, peer)
+				updated = true
+				continue
+			}
+			results = append(results, p)
+		}
+		if !updated {
+			results = append(results, peer)
+		}
+		return results
+	})
+}
+
+// deleteLocalRouterPeer ローカルルータからピア設定を削除する。ローカルルータが存在しない場合は何もしない
+func deleteLocalRouterPeer(ctx context.Context, lrOp sacloud.LocalRouterAPI, id, peerID types.ID) error {
+	err := updateLocalRouterPeers(ctx, lrOp, id, func(peers []*sacloud.LocalRouterPeer) []*sacloud.LocalRouterPeer {
+		var results []*sacloud.LocalRouterPeer
+		for _, p := range peers {
+			if p.ID != peerID {
+				results = append(results, p)
+			}
+		}
+		return results
+	})
+	if err != nil && sacloud.IsNotFoundError(err) {
+		return nil
+	}
+	return err
+}
+
+func updateLocalRouterPeers(ctx context.Context, lrOp sacloud.LocalRouterAPI, id types.ID, fn func([]*sacloud.LocalRouterPeer) []*sacloud.LocalRouterPeer) error {
+	sakuraMutexKV.Lock(id.String())
+	defer sakuraMutexKV.Unlock(id.String())
+
+	localRouter, err := lrOp.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = lrOp.UpdateSettings(ctx, id, &sacloud.LocalRouterUpdateSettingsRequest{
+		Switch:       localRouter.Switch,
+		Interface:    localRouter.Interface,
+		Peers:        fn(localRouter.Peers),
+		StaticRoutes: localRouter.StaticRoutes,
+		SettingsHash: localRouter.SettingsHash,
+	})
+	return err
+}
@@ -0,0 +1,323 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccSakuraCloudLocalRouterPeer_basic(t *testing.T) {
+	resourceName := "sakuracloud_local_router_peer.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudLocalRouterPeerDestroy,
+			testCheckSakuraCloudLocalRouterDestroy,
+			testCheckSakuraCloudSwitchDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudLocalRouterPeer_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudLocalRouterPeerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bidirectional", "true"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "description", "description"),
+				),
+			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudLocalRouterPeer_update, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudLocalRouterPeerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bidirectional", "true"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "description", "description-upd"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"status", "routes"},
+			},
+		},
+	})
+}
+
+func TestAccSakuraCloudLocalRouterPeer_withSecretKey(t *testing.T) {
+	resourceName := "sakuracloud_local_router_peer.foobar"
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudLocalRouterPeerDestroy,
+			testCheckSakuraCloudLocalRouterDestroy,
+			testCheckSakuraCloudSwitchDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudLocalRouterPeer_withSecretKey, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudLocalRouterPeerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bidirectional", "false"),
+					resource.TestCheckResourceAttrPair(resourceName, "secret_key", "sakuracloud_local_router.foobar2", "secret_keys.0"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckSakuraCloudLocalRouterPeerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return errors.New("no LocalRouter Peer ID is set")
+		}
+
+		client := testAccProvider.Meta().(*APIClient)
+		lrOp := sacloud.NewLocalRouterOp(client)
+		localRouterID, peerID, err := expandLocalRouterPeerID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		localRouter, err := lrOp.Read(context.Background(), localRouterID)
+		if err != nil {
+			return err
+		}
+		if findLocalRouterPeer(localRouter, peerID) == nil {
+			return fmt.Errorf("not found LocalRouter Peer: %s", rs.Primary.ID)
+		}
+
+		if rs.Primary.Attributes["bidirectional"] == "true" {
+			peerRouter, err := lrOp.Read(context.Background(), peerID)
+			if err != nil {
+				return err
+			}
+			if findLocalRouterPeer(peerRouter, localRouterID) == nil {
+				return fmt.Errorf("not found LocalRouter Peer on LocalRouter[%s]: %s", peerID, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testCheckSakuraCloudLocalRouterPeerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*APIClient)
+	lrOp := sacloud.NewLocalRouterOp(client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sakuracloud_local_router_peer" {
+			continue
+		}
+		if rs.Primary.ID == "" {
+			continue
+		}
+
+		localRouterID, peerID, err := expandLocalRouterPeerID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		localRouter, err := lrOp.Read(context.Background(), localRouterID)
+		if err == nil && findLocalRouterPeer(localRouter, peerID) != nil {
+			return fmt.Errorf("still exists LocalRouter Peer: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+type dummyLocalRouterPeerOp struct {
+	sacloud.LocalRouterAPI
+	localRouter *sacloud.LocalRouter
+	updated     *sacloud.LocalRouterUpdateSettingsRequest
+}
+
+func (o *dummyLocalRouterPeerOp) Read(_ context.Context, id types.ID) (*sacloud.LocalRouter, error) {
+	if id != o.localRouter.ID {
+		return nil, sacloud.NewAPIError("GET", nil, "", 404, nil)
+	}
+	return o.localRouter, nil
+}
+
+func (o *dummyLocalRouterPeerOp) UpdateSettings(_ context.Context, _ types.ID, param *sacloud.LocalRouterUpdateSettingsRequest) (*sacloud.LocalRouter, error) {
+	o.updated = param
+	return o.localRouter, nil
+}
+
+func TestLocalRouterPeer_updateLocalRouterPeer(t *testing.T) {
+	op := &dummyLocalRouterPeerOp{
+		localRouter: &sacloud.LocalRouter{
+			ID:           types.ID(111111111111),
+			Switch:       &sacloud.LocalRouterSwitch{Code: "999999999999", Category: "cloud", ZoneID: "is1a"},
+			Interface:    &sacloud.LocalRouterInterface{VirtualIPAddress: "192.168.11.1"},
+			StaticRoutes: []*sacloud.LocalRouterStaticRoute{{Prefix: "10.0.0.0/24", NextHop: "192.168.11.2"}},
+			SettingsHash: "hash",
+			Peers: []*sacloud.LocalRouterPeer{
+				{ID: types.ID(222222222222), SecretKey: "secret2", Enabled: true},
+				{ID: types.ID(333333333333), SecretKey: "secret3", Enabled: true},
+			},
+		},
+	}
+	ctx := context.Background()
+
+	// 既存のピアは更新、その他の設定は維持する
+	err := updateLocalRouterPeer(ctx, op, types.ID(111111111111), &sacloud.LocalRouterPeer{ID: types.ID(333333333333), SecretKey: "secret3", Description: "upd"})
+	assert.NoError(t, err)
+	assert.Len(t, op.updated.Peers, 2)
+	assert.Equal(t, "upd", op.updated.Peers[1].Description)
+	assert.False(t, op.updated.Peers[1].Enabled)
+	assert.Equal(t, op.localRouter.Switch, op.updated.Switch)
+	assert.Equal(t, op.localRouter.Interface, op.updated.Interface)
+	assert.Equal(t, op.localRouter.StaticRoutes, op.updated.StaticRoutes)
+	assert.Equal(t, "hash", op.updated.SettingsHash)
+
+	// 新しいピアは追加する
+	err = updateLocalRouterPeer(ctx, op, types.ID(111111111111), &sacloud.LocalRouterPeer{ID: types.ID(444444444444), SecretKey: "secret4", Enabled: true})
+	assert.NoError(t, err)
+	assert.Len(t, op.updated.Peers, 3)
+
+	err = deleteLocalRouterPeer(ctx, op, types.ID(111111111111), types.ID(222222222222))
+	assert.NoError(t, err)
+	assert.Len(t, op.updated.Peers, 1)
+	assert.Equal(t, types.ID(333333333333), op.updated.Peers[0].ID)
+
+	// 存在しないローカルルータからの削除はエラーにしない
+	assert.NoError(t, deleteLocalRouterPeer(ctx, op, types.ID(999999999999), types.ID(222222222222)))
+}
+
+func TestLocalRouterPeer_expandLocalRouterPeerID(t *testing.T) {
+	localRouterID, peerID, err := expandLocalRouterPeerID("111111111111/222222222222")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ID(111111111111), localRouterID)
+	assert.Equal(t, types.ID(222222222222), peerID)
+	assert.Equal(t, "111111111111/222222222222", localRouterPeerID(localRouterID, peerID))
+
+	for _, id := range []string{"111111111111", "111111111111/", "a/b", "1/2/3"} {
+		_, _, err := expandLocalRouterPeerID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestLocalRouterPeer_flattenLocalRouterPeerHealth(t *testing.T) {
+	health := &sacloud.LocalRouterHealth{
+		Peers: []*sacloud.LocalRouterHealthPeer{
+			{ID: types.ID(222222222222), Status: types.ServerInstanceStatuses.Up, Routes: []string{"192.168.12.0/24"}},
+		},
+	}
+
+	status, routes := flattenLocalRouterPeerHealth(health, types.ID(222222222222))
+	assert.Equal(t, "up", status)
+	assert.Equal(t, []string{"192.168.12.0/24"}, routes)
+
+	status, routes = flattenLocalRouterPeerHealth(health, types.ID(333333333333))
+	assert.Equal(t, "", status)
+	assert.Nil(t, routes)
+
+	status, _ = flattenLocalRouterPeerHealth(nil, types.ID(222222222222))
+	assert.Equal(t, "", status)
+}
+
+var testAccSakuraCloudLocalRouterPeer_routers = `
+resource "sakuracloud_switch" "foobar1" {
+  name = "{{ .arg0 }}"
+}
+resource "sakuracloud_switch" "foobar2" {
+  name = "{{ .arg0 }}"
+}
+
+data sakuracloud_zone "current" {}
+
+resource "sakuracloud_local_router" "foobar1" {
+  switch {
+    code     = sakuracloud_switch.foobar1.id
+    category = "cloud"
+    zone_id  = data.sakuracloud_zone.current.name
+  }
+  network_interface {
+    vip          = "192.168.11.1"
+    ip_addresses = ["192.168.11.11", "192.168.11.12"]
+    netmask      = 24
+    vrid         = 1
+  }
+
+  name = "{{ .arg0 }}"
+
+  lifecycle {
+    ignore_changes = [peer]
+  }
+}
+
+resource "sakuracloud_local_router" "foobar2" {
+  switch {
+    code     = sakuracloud_switch.foobar2.id
+    category = "cloud"
+    zone_id  = data.sakuracloud_zone.current.name
+  }
+  network_interface {
+    vip          = "192.168.12.1"
+    ip_addresses = ["192.168.12.11", "192.168.12.12"]
+    netmask      = 24
+    vrid         = 1
+  }
+
+  name = "{{ .arg0 }}"
+
+  lifecycle {
+    ignore_changes = [peer]
+  }
+}
+`
+
+var testAccSakuraCloudLocalRouterPeer_basic = testAccSakuraCloudLocalRouterPeer_routers + `
+resource "sakuracloud_local_router_peer" "foobar" {
+  local_router_id = sakuracloud_local_router.foobar1.id
+  peer_id         = sakuracloud_local_router.foobar2.id
+  description     = "description"
+}
+`
+
+var testAccSakuraCloudLocalRouterPeer_update = testAccSakuraCloudLocalRouterPeer_routers + `
+resource "sakuracloud_local_router_peer" "foobar" {
+  local_router_id = sakuracloud_local_router.foobar1.id
+  peer_id         = sakuracloud_local_router.foobar2.id
+  enabled         = false
+  description     = "description-upd"
+}
+`
+
+var testAccSakuraCloudLocalRouterPeer_withSecretKey = testAccSakuraCloudLocalRouterPeer_routers + `
+resource "sakuracloud_local_router_peer" "foobar" {
+  local_router_id = sakuracloud_local_router.foobar1.id
+  peer_id         = sakuracloud_local_router.foobar2.id
+  secret_key      = sakuracloud_local_router.foobar2.secret_keys.0
+}
+`
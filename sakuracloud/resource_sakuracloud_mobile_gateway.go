@@ -35,8 +35,9 @@ func resourceSakuraCloudMobileGateway() *schema.Resource {
 		ReadContext:   resourceSakuraCloudMobileGatewayRead,
 		UpdateContext: resourceSakuraCloudMobileGatewayUpdate,
 		DeleteContext: resourceSakuraCloudMobileGatewayDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudMobileGatewayRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -211,6 +212,7 @@ func resourceSakuraCloudMobileGateway() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 		},
 	}
@@ -355,7 +357,7 @@ func setMobileGatewayResourceData(ctx context.Context, d *schema.ResourceData, c
 	d.Set("name", data.Name)               // nolint
 	d.Set("icon_id", data.IconID.String()) // nolint
 	d.Set("description", data.Description) // nolint
-	if err := d.Set("tags", flattenTags(data.Tags)); err != nil {
+	if err := setResourceTags(d, client, data.Tags); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("sim", flattenMobileGatewaySIMs(sims)); err != nil {
@@ -36,8 +36,9 @@ func resourceSakuraCloudNFS() *schema.Resource {
 		ReadContext:   resourceSakuraCloudNFSRead,
 		UpdateContext: resourceSakuraCloudNFSUpdate,
 		DeleteContext: resourceSakuraCloudNFSDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudNFSRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -87,6 +88,7 @@ func resourceSakuraCloudNFS() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 		},
 	}
@@ -106,7 +108,7 @@ func resourceSakuraCloudNFSCreate(ctx context.Context, d *schema.ResourceData, m
 
 	builder := &setup.RetryableSetup{
 		Create: func(ctx context.Context, zone string) (accessor.ID, error) {
-			return nfsOp.Create(ctx, zone, expandNFSCreateRequest(d, planID))
+			return nfsOp.Create(ctx, zone, expandNFSCreateRequest(d, client, planID))
 		},
 		Delete: func(ctx context.Context, zone string, id types.ID) error {
 			return nfsOp.Delete(ctx, zone, id)
@@ -164,7 +166,7 @@ func resourceSakuraCloudNFSUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("could not read SakuraCloud NFS[%s]: %s", d.Id(), err)
 	}
 
-	_, err = nfsOp.Update(ctx, zone, nfs.ID, expandNFSUpdateRequest(d))
+	_, err = nfsOp.Update(ctx, zone, nfs.ID, expandNFSUpdateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud NFS[%s] is failed: %s", d.Id(), err)
 	}
@@ -218,5 +220,5 @@ func setNFSResourceData(ctx context.Context, d *schema.ResourceData, client *API
 	d.Set("icon_id", data.IconID.String()) // nolint
 	d.Set("description", data.Description) // nolint
 	d.Set("zone", getZone(d, client))      // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
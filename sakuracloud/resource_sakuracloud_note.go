@@ -32,8 +32,9 @@ func resourceSakuraCloudNote() *schema.Resource {
 		ReadContext:   resourceSakuraCloudNoteRead,
 		UpdateContext: resourceSakuraCloudNoteUpdate,
 		DeleteContext: resourceSakuraCloudNoteDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudNoteRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -59,8 +60,9 @@ func resourceSakuraCloudNote() *schema.Resource {
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(types.NoteClassStrings, false)),
 				Description:      descf("The class of the %s. This must be one of %s", resourceName, types.NoteClassStrings),
 			},
-			"icon_id": schemaResourceIconID(resourceName),
-			"tags":    schemaResourceTags(resourceName),
+			"icon_id":  schemaResourceIconID(resourceName),
+			"tags":     schemaResourceTags(resourceName),
+			"tags_all": schemaResourceTagsAll(resourceName),
 			"description": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -77,7 +79,7 @@ func resourceSakuraCloudNoteCreate(ctx context.Context, d *schema.ResourceData,
 	}
 
 	noteOp := sacloud.NewNoteOp(client)
-	note, err := noteOp.Create(ctx, expandNoteCreateRequest(d))
+	note, err := noteOp.Create(ctx, expandNoteCreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud Note is failed: %s", err)
 	}
@@ -117,7 +119,7 @@ func resourceSakuraCloudNoteUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("could not read SakuraCloud Note[%s]: %s", d.Id(), err)
 	}
 
-	_, err = noteOp.Update(ctx, note.ID, expandNoteUpdateRequest(d))
+	_, err = noteOp.Update(ctx, note.ID, expandNoteUpdateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud Note[%s] is failed: %s", d.Id(), err)
 	}
@@ -153,5 +155,5 @@ func setNoteResourceData(ctx context.Context, d *schema.ResourceData, client *AP
 	d.Set("class", data.Class)             // nolint
 	d.Set("icon_id", data.IconID.String()) // nolint
 	d.Set("description", data.Description) // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
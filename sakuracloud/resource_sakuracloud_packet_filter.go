@@ -35,9 +35,11 @@ func resourceSakuraCloudPacketFilter() *schema.Resource {
 		UpdateContext: resourceSakuraCloudPacketFilterUpdate,
 		DeleteContext: resourceSakuraCloudPacketFilterDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudPacketFilterRead),
 		},
 
+		CustomizeDiff: customizeDiffPacketFilterExpressions,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Update: schema.DefaultTimeout(5 * time.Minute),
@@ -95,6 +97,12 @@ func resourceSakuraCloudPacketFilter() *schema.Resource {
 					},
 				},
 			},
+			"expression_warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of warnings found by analyzing the expressions, such as shadowed, duplicate or unreachable rules",
+			},
 			"zone": schemaResourceZone(resourceName),
 		},
 	}
@@ -180,8 +188,9 @@ func resourceSakuraCloudPacketFilterDelete(ctx context.Context, d *schema.Resour
 }
 
 func setPacketFilterResourceData(ctx context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.PacketFilter) diag.Diagnostics {
-	d.Set("name", data.Name)               // nolint
-	d.Set("description", data.Description) // nolint
-	d.Set("zone", getZone(d, client))      // nolint
+	d.Set("name", data.Name)                                                  // nolint
+	d.Set("description", data.Description)                                    // nolint
+	d.Set("zone", getZone(d, client))                                         // nolint
+	d.Set("expression_warnings", flattenPacketFilterExpressionWarnings(data)) // nolint
 	return diag.FromErr(d.Set("expression", flattenPacketFilterExpressions(data)))
 }
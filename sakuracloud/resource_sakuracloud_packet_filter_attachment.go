@@ -0,0 +1,254 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func resourceSakuraCloudPacketFilterAttachment() *schema.Resource {
+	resourceName := "PacketFilter Attachment"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudPacketFilterAttachmentCreate,
+		ReadContext:   resourceSakuraCloudPacketFilterAttachmentRead,
+		UpdateContext: resourceSakuraCloudPacketFilterAttachmentUpdate,
+		DeleteContext: resourceSakuraCloudPacketFilterAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudPacketFilterAttachmentRead),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"packet_filter_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the packet filter to attach to the network interface",
+			},
+			"server_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"server_id", "interface_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Server which has the network interface. Either `server_id` or `interface_id` must be specified",
+			},
+			"nic_index": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"interface_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 9)),
+				Description: descf(
+					"The index of the network interface of the Server specified by `server_id`. %s",
+					descRange(0, 9),
+				),
+			},
+			"interface_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"server_id", "interface_id"},
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the network interface to which the packet filter is attached. Either `server_id` or `interface_id` must be specified",
+			},
+			"zone": schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudPacketFilterAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	iface, err := expandPacketFilterAttachmentInterface(ctx, d, client, zone)
+	if err != nil {
+		return diag.Errorf("creating SakuraCloud PacketFilter Attachment is failed: %s", err)
+	}
+
+	sakuraMutexKV.Lock(iface.ServerID.String())
+	defer sakuraMutexKV.Unlock(iface.ServerID.String())
+
+	packetFilterID := expandSakuraCloudID(d, "packet_filter_id")
+	if !iface.PacketFilterID.IsEmpty() && iface.PacketFilterID != packetFilterID {
+		return diag.Errorf(
+			"creating SakuraCloud PacketFilter Attachment is failed: NetworkInterface[%s] is already connected to PacketFilter[%s]",
+			iface.ID, iface.PacketFilterID,
+		)
+	}
+	if iface.PacketFilterID.IsEmpty() {
+		if err := sacloud.NewInterfaceOp(client).ConnectToPacketFilter(ctx, zone, iface.ID, packetFilterID); err != nil {
+			return diag.Errorf("creating SakuraCloud PacketFilter Attachment is failed: %s", err)
+		}
+	}
+
+	d.SetId(iface.ID.String())
+	return resourceSakuraCloudPacketFilterAttachmentRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudPacketFilterAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	iface, err := sacloud.NewInterfaceOp(client).Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud PacketFilter Attachment[%s]: %s", d.Id(), err)
+	}
+	if iface.ServerID.IsEmpty() {
+		d.SetId("")
+		return nil
+	}
+
+	server, err := sacloud.NewServerOp(client).Read(ctx, zone, iface.ServerID)
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud Server[%s]: %s", iface.ServerID, err)
+	}
+	return setPacketFilterAttachmentResourceData(ctx, d, client, iface, server)
+}
+
+func resourceSakuraCloudPacketFilterAttachmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceOp := sacloud.NewInterfaceOp(client)
+	iface, err := interfaceOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud PacketFilter Attachment[%s]: %s", d.Id(), err)
+	}
+
+	sakuraMutexKV.Lock(iface.ServerID.String())
+	defer sakuraMutexKV.Unlock(iface.ServerID.String())
+
+	packetFilterID := expandSakuraCloudID(d, "packet_filter_id")
+	if iface.PacketFilterID != packetFilterID {
+		if !iface.PacketFilterID.IsEmpty() {
+			if err := interfaceOp.DisconnectFromPacketFilter(ctx, zone, iface.ID); err != nil {
+				return diag.Errorf("updating SakuraCloud PacketFilter Attachment[%s] is failed: %s", d.Id(), err)
+			}
+		}
+		if err := interfaceOp.ConnectToPacketFilter(ctx, zone, iface.ID, packetFilterID); err != nil {
+			return diag.Errorf("updating SakuraCloud PacketFilter Attachment[%s] is failed: %s", d.Id(), err)
+		}
+	}
+	return resourceSakuraCloudPacketFilterAttachmentRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudPacketFilterAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceOp := sacloud.NewInterfaceOp(client)
+	iface, err := interfaceOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud PacketFilter Attachment[%s]: %s", d.Id(), err)
+	}
+
+	sakuraMutexKV.Lock(iface.ServerID.String())
+	defer sakuraMutexKV.Unlock(iface.ServerID.String())
+
+	// 他のパケットフィルタへ付け替えられている場合はそのままにしておく
+	if iface.PacketFilterID.IsEmpty() || iface.PacketFilterID != expandSakuraCloudID(d, "packet_filter_id") {
+		d.SetId("")
+		return nil
+	}
+	if err := interfaceOp.DisconnectFromPacketFilter(ctx, zone, iface.ID); err != nil {
+		return diag.Errorf("deleting SakuraCloud PacketFilter Attachment[%s] is failed: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// expandPacketFilterAttachmentInterface interface_id、またはserver_idとnic_indexで指定されたNICを返す
+func expandPacketFilterAttachmentInterface(ctx context.Context, d resourceValueGettable, client *APIClient, zone string) (*sacloud.Interface, error) {
+	interfaceID := expandSakuraCloudID(d, "interface_id")
+	if interfaceID.IsEmpty() {
+		serverID := expandSakuraCloudID(d, "server_id")
+		server, err := sacloud.NewServerOp(client).Read(ctx, zone, serverID)
+		if err != nil {
+			return nil, fmt.Errorf("could not read SakuraCloud Server[%s]: %s", serverID, err)
+		}
+		index := intOrDefault(d, "nic_index")
+		if index >= len(server.Interfaces) {
+			return nil, fmt.Errorf("Server[%s] doesn't have the network interface at index %d", serverID, index)
+		}
+		interfaceID = server.Interfaces[index].ID
+	}
+
+	iface, err := sacloud.NewInterfaceOp(client).Read(ctx, zone, interfaceID)
+	if err != nil {
+		return nil, fmt.Errorf("could not read SakuraCloud NetworkInterface[%s]: %s", interfaceID, err)
+	}
+	if iface.ServerID.IsEmpty() {
+		return nil, fmt.Errorf("NetworkInterface[%s] is not connected to any Server", interfaceID)
+	}
+	return iface, nil
+}
+
+// flattenPacketFilterAttachmentNICIndex サーバのNICのうちinterfaceIDのインデックスを返す、見つからない場合は-1を返す
+func flattenPacketFilterAttachmentNICIndex(server *sacloud.Server, interfaceID types.ID) int {
+	for i, nic := range server.Interfaces {
+		if nic.ID == interfaceID {
+			return i
+		}
+	}
+	return -1
+}
+
+func setPacketFilterAttachmentResourceData(_ context.Context, d *schema.ResourceData, client *APIClient, iface *sacloud.Interface, server *sacloud.Server) diag.Diagnostics {
+	// 管理画面などでパケットフィルタが外された/付け替えられた場合は差分として検出する
+	d.Set("packet_filter_id", iface.PacketFilterID.String())                    // nolint
+	d.Set("interface_id", iface.ID.String())                                    // nolint
+	d.Set("server_id", iface.ServerID.String())                                 // nolint
+	d.Set("nic_index", flattenPacketFilterAttachmentNICIndex(server, iface.ID)) // nolint
+	d.Set("zone", getZone(d, client))                                           // nolint
+	return nil
+}
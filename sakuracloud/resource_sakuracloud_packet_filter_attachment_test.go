@@ -0,0 +1,170 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func TestAccSakuraCloudPacketFilterAttachment_basic(t *testing.T) {
+	resourceName := "sakuracloud_packet_filter_attachment.foobar"
+	rand := randomName()
+
+	var iface sacloud.Interface
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudServerDestroy,
+			testCheckSakuraCloudPacketFilterDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudPacketFilterAttachment_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudPacketFilterAttachmentExists(resourceName, &iface),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "packet_filter_id",
+						"sakuracloud_packet_filter.foobar", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "server_id",
+						"sakuracloud_server.foobar", "id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "nic_index", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "interface_id"),
+				),
+			},
+			{
+				// 管理画面などでパケットフィルタが外された場合を再現する
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*APIClient)
+					if err := sacloud.NewInterfaceOp(client).DisconnectFromPacketFilter(context.Background(), client.defaultZone, iface.ID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: buildConfigWithArgs(testAccSakuraCloudPacketFilterAttachment_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudPacketFilterAttachmentExists(resourceName, &iface),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "packet_filter_id",
+						"sakuracloud_packet_filter.foobar", "id",
+					),
+				),
+			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudPacketFilterAttachment_update, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudPacketFilterAttachmentExists(resourceName, &iface),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "packet_filter_id",
+						"sakuracloud_packet_filter.foobar2", "id",
+					),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckSakuraCloudPacketFilterAttachmentExists(n string, iface *sacloud.Interface) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("no PacketFilter Attachment ID is set")
+		}
+
+		client := testAccProvider.Meta().(*APIClient)
+		zone := rs.Primary.Attributes["zone"]
+		foundInterface, err := sacloud.NewInterfaceOp(client).Read(context.Background(), zone, sakuraCloudID(rs.Primary.ID))
+		if err != nil {
+			return err
+		}
+
+		if foundInterface.PacketFilterID.String() != rs.Primary.Attributes["packet_filter_id"] {
+			return fmt.Errorf("PacketFilter[%s] is not attached to NetworkInterface[%s]", rs.Primary.Attributes["packet_filter_id"], rs.Primary.ID)
+		}
+
+		*iface = *foundInterface
+		return nil
+	}
+}
+
+var testAccSakuraCloudPacketFilterAttachment_basic = `
+resource "sakuracloud_packet_filter" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "{{ .arg0 }}"
+  network_interface {
+    upstream = "shared"
+  }
+  force_shutdown = true
+
+  lifecycle {
+    ignore_changes = [network_interface[0].packet_filter_id]
+  }
+}
+
+resource "sakuracloud_packet_filter_attachment" "foobar" {
+  server_id        = sakuracloud_server.foobar.id
+  nic_index        = 0
+  packet_filter_id = sakuracloud_packet_filter.foobar.id
+}
+`
+
+var testAccSakuraCloudPacketFilterAttachment_update = `
+resource "sakuracloud_packet_filter" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_packet_filter" "foobar2" {
+  name = "{{ .arg0 }}-upd"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "{{ .arg0 }}"
+  network_interface {
+    upstream = "shared"
+  }
+  force_shutdown = true
+
+  lifecycle {
+    ignore_changes = [network_interface[0].packet_filter_id]
+  }
+}
+
+resource "sakuracloud_packet_filter_attachment" "foobar" {
+  server_id        = sakuracloud_server.foobar.id
+  nic_index        = 0
+  packet_filter_id = sakuracloud_packet_filter.foobar2.id
+}
+`
@@ -32,9 +32,11 @@ func resourceSakuraCloudPacketFilterRules() *schema.Resource {
 		ReadContext:   resourceSakuraCloudPacketFilterRulesRead,
 		DeleteContext: resourceSakuraCloudPacketFilterRulesDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudPacketFilterRulesRead),
 		},
 
+		CustomizeDiff: customizeDiffPacketFilterExpressions,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
@@ -102,6 +104,12 @@ func resourceSakuraCloudPacketFilterRules() *schema.Resource {
 					},
 				},
 			},
+			"expression_warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A list of warnings found by analyzing the expressions, such as shadowed, duplicate or unreachable rules",
+			},
 			"zone": schemaResourceZone(resourceName),
 		},
 	}
@@ -182,6 +190,7 @@ func resourceSakuraCloudPacketFilterRulesDelete(ctx context.Context, d *schema.R
 }
 
 func setPacketFilterRulesResourceData(ctx context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.PacketFilter) diag.Diagnostics {
-	d.Set("zone", getZone(d, client)) // nolint
+	d.Set("zone", getZone(d, client))                                         // nolint
+	d.Set("expression_warnings", flattenPacketFilterExpressionWarnings(data)) // nolint
 	return diag.FromErr(d.Set("expression", flattenPacketFilterExpressions(data)))
 }
@@ -35,8 +35,9 @@ func resourceSakuraCloudPrivateHost() *schema.Resource {
 		ReadContext:   resourceSakuraCloudPrivateHostRead,
 		UpdateContext: resourceSakuraCloudPrivateHostUpdate,
 		DeleteContext: resourceSakuraCloudPrivateHostDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudPrivateHostRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -57,6 +58,7 @@ func resourceSakuraCloudPrivateHost() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"hostname": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -89,7 +91,7 @@ func resourceSakuraCloudPrivateHostCreate(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("creating SakuraCloud PrivateHost is failed: %s", err)
 	}
 
-	ph, err := phOp.Create(ctx, zone, expandPrivateHostCreateRequest(d, planID))
+	ph, err := phOp.Create(ctx, zone, expandPrivateHostCreateRequest(d, client, planID))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud PrivateHost is failed: %s", err)
 	}
@@ -129,7 +131,7 @@ func resourceSakuraCloudPrivateHostUpdate(ctx context.Context, d *schema.Resourc
 		return diag.Errorf("could not read SakuraCloud PrivateHost[%s]: %s", d.Id(), err)
 	}
 
-	_, err = phOp.Update(ctx, zone, ph.ID, expandPrivateHostUpdateRequest(d))
+	_, err = phOp.Update(ctx, zone, ph.ID, expandPrivateHostUpdateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud PrivateHost[%s] is failed: %s", d.Id(), err)
 	}
@@ -170,5 +172,5 @@ func setPrivateHostResourceData(ctx context.Context, d *schema.ResourceData, cli
 	d.Set("assigned_core", data.GetAssignedCPU())        // nolint
 	d.Set("assigned_memory", data.GetAssignedMemoryGB()) // nolint
 	d.Set("zone", getZone(d, client))                    // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -33,8 +33,9 @@ func resourceSakuraCloudProxyLB() *schema.Resource {
 		ReadContext:   resourceSakuraCloudProxyLBRead,
 		UpdateContext: resourceSakuraCloudProxyLBUpdate,
 		DeleteContext: resourceSakuraCloudProxyLBDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudProxyLBRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -369,6 +370,7 @@ func resourceSakuraCloudProxyLB() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"fqdn": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -396,7 +398,7 @@ func resourceSakuraCloudProxyLBCreate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	proxyLBOp := sacloud.NewProxyLBOp(client)
-	proxyLB, err := proxyLBOp.Create(ctx, expandProxyLBCreateRequest(d))
+	proxyLB, err := proxyLBOp.Create(ctx, expandProxyLBCreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SakuraCloud ProxyLB is failed: %s", err)
 	}
@@ -451,7 +453,7 @@ func resourceSakuraCloudProxyLBUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("could not read SakuraCloud ProxyLB[%s]: %s", d.Id(), err)
 	}
 
-	proxyLB, err = proxyLBOp.Update(ctx, proxyLB.ID, expandProxyLBUpdateRequest(d))
+	proxyLB, err = proxyLBOp.Update(ctx, proxyLB.ID, expandProxyLBUpdateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("updating SakuraCloud ProxyLB[%s] is failed: %s", d.Id(), err)
 	}
@@ -559,5 +561,5 @@ func setProxyLBResourceData(ctx context.Context, d *schema.ResourceData, client
 	if err := d.Set("certificate", flattenProxyLBCerts(certs)); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
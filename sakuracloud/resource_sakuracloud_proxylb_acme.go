@@ -30,7 +30,7 @@ func resourceSakuraCloudProxyLBACME() *schema.Resource {
 		ReadContext:   resourceSakuraCloudProxyLBACMERead,
 		DeleteContext: resourceSakuraCloudProxyLBACMEDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext("ProxyLB", resourceSakuraCloudProxyLBACMERead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -16,10 +16,12 @@ package sakuracloud
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sacloud/libsacloud/v2/helper/power"
@@ -35,8 +37,12 @@ func resourceSakuraCloudServer() *schema.Resource {
 		UpdateContext: resourceSakuraCloudServerUpdate,
 		ReadContext:   resourceSakuraCloudServerRead,
 		DeleteContext: resourceSakuraCloudServerDelete,
+		CustomizeDiff: customdiff.All(
+			resourceSakuraCloudServerCustomizeDiff,
+			customizeDiffTagsAll,
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSakuraCloudServerImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -88,7 +94,7 @@ func resourceSakuraCloudServer() *schema.Resource {
 			"network_interface": {
 				Type:     schema.TypeList,
 				Optional: true,
-				MaxItems: 10,
+				MaxItems: serverNetworkInterfaceMaxCount,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"upstream": {
@@ -121,6 +127,11 @@ func resourceSakuraCloudServer() *schema.Resource {
 					},
 				},
 			},
+			"managed_network_interface_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of the network interfaces managed by `network_interface`. The network interfaces after them are managed by other resources such as `sakuracloud_server_network_interface`",
+			},
 			"cdrom_id": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -141,6 +152,7 @@ func resourceSakuraCloudServer() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 			"disk_edit_parameter": {
 				Type:     schema.TypeList,
@@ -307,6 +319,7 @@ func resourceSakuraCloudServerCreate(ctx context.Context, d *schema.ResourceData
 	}
 
 	d.SetId(result.ServerID.String())
+	d.Set("managed_network_interface_count", len(d.Get("network_interface").([]interface{}))) // nolint
 	return resourceSakuraCloudServerRead(ctx, d, meta)
 }
 
@@ -326,7 +339,20 @@ func resourceSakuraCloudServerRead(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("could not read SakuraCloud Server[%s]: %s", d.Id(), err)
 	}
 
-	return setServerResourceData(ctx, d, client, server)
+	managedNICCount := serverManagedNICCount(
+		d.Get("managed_network_interface_count").(int),
+		d.Get("network_interface").([]interface{}),
+		server,
+	)
+	if diags := setServerResourceData(ctx, d, client, server); diags.HasError() {
+		return diags
+	}
+	// sakuracloud_server_network_interfaceなどで管理されているNICは除外する
+	if err := d.Set("network_interface", flattenServerNICs(server)[:managedNICCount]); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("managed_network_interface_count", managedNICCount) // nolint
+	return nil
 }
 
 func resourceSakuraCloudServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -349,6 +375,11 @@ func resourceSakuraCloudServerUpdate(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	o, n := d.GetChange("network_interface")
+	managedNICCount := serverManagedNICCount(d.Get("managed_network_interface_count").(int), o.([]interface{}), server)
+	if err := expandServerUnmanagedNICs(d, builder, server, managedNICCount); err != nil {
+		return diag.Errorf("updating SakuraCloud Server[%s] is failed: %s", server.ID, err)
+	}
 
 	if err := builder.Validate(ctx, zone); err != nil {
 		return diag.Errorf("validating SakuraCloud Server[%s] is failed: %s", server.ID, err)
@@ -360,9 +391,16 @@ func resourceSakuraCloudServerUpdate(ctx context.Context, d *schema.ResourceData
 	}
 
 	d.SetId(result.ServerID.String())
+	d.Set("managed_network_interface_count", len(n.([]interface{}))) // nolint
 	return resourceSakuraCloudServerRead(ctx, d, meta)
 }
 
+// resourceSakuraCloudServerImport インポート時はサーバーの全てのNICをnetwork_interfaceで管理する
+func resourceSakuraCloudServerImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("managed_network_interface_count", serverNetworkInterfaceMaxCount) // nolint
+	return importZonedResourceStateContext("Server", resourceSakuraCloudServerRead)(ctx, d, meta)
+}
+
 func resourceSakuraCloudServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, zone, err := sakuraCloudClient(d, meta)
 	if err != nil {
@@ -395,6 +433,43 @@ func resourceSakuraCloudServerDelete(ctx context.Context, d *schema.ResourceData
 	return nil
 }
 
+// resourceSakuraCloudServerCustomizeDiff core/memory/commitmentの組み合わせが対象ゾーンに存在するプランか検証する
+func resourceSakuraCloudServerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	keys := []string{"core", "memory", "commitment", "zone"}
+	changed := d.Id() == ""
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+		if d.HasChange(key) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	core := d.Get("core").(int)
+	memory := d.Get("memory").(int)
+	commitment := types.ECommitment(d.Get("commitment").(string))
+
+	_, err = query.FindServerPlan(ctx, sacloud.NewServerPlanOp(client), zone, &query.FindServerPlanRequest{
+		CPU:        core,
+		MemoryGB:   memory,
+		Commitment: commitment,
+		Generation: types.PlanGenerations.Default,
+	})
+	if err != nil {
+		return fmt.Errorf("server plan[core: %d, memory: %dGB, commitment: %s] is not available in zone %q: %s", core, memory, commitment, zone, err)
+	}
+	return nil
+}
+
 func setServerResourceData(ctx context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.Server) diag.Diagnostics {
 	zone := getZone(d, client)
 
@@ -440,5 +515,5 @@ func setServerResourceData(ctx context.Context, d *schema.ResourceData, client *
 		return diag.FromErr(err)
 	}
 	d.Set("zone", zone) // nolint
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
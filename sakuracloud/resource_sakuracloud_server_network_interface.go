@@ -0,0 +1,304 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/helper/power"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func resourceSakuraCloudServerNetworkInterface() *schema.Resource {
+	resourceName := "NetworkInterface"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudServerNetworkInterfaceCreate,
+		ReadContext:   resourceSakuraCloudServerNetworkInterfaceRead,
+		UpdateContext: resourceSakuraCloudServerNetworkInterfaceUpdate,
+		DeleteContext: resourceSakuraCloudServerNetworkInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudServerNetworkInterfaceRead),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      descf("The id of the Server to which the %s is added", resourceName),
+			},
+			"upstream": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "disconnect",
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuraCloudServerNIC),
+				Description: descf(
+					"The upstream type or upstream switch id. This must be one of [%s]. `shared` is only available for the first network interface of the Server",
+					[]string{"shared", "disconnect", "<switch id>"},
+				),
+			},
+			"packet_filter_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      descf("The id of the packet filter to attach to the %s", resourceName),
+			},
+			"user_ip_address": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPv4Address),
+				Description:      "The IP address for only display. This value doesn't affect actual NIC settings",
+			},
+			"mac_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The MAC address",
+			},
+			"ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: descf("The IP address assigned to the %s when connected to the shared segment", resourceName),
+			},
+			"force_shutdown": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "The flag to use force shutdown when need to shutdown the server while adding/removing or connecting/disconnecting the network interface",
+			},
+			"zone": schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudServerNetworkInterfaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := expandSakuraCloudID(d, "server_id")
+	sakuraMutexKV.Lock(serverID.String())
+	defer sakuraMutexKV.Unlock(serverID.String())
+
+	interfaceOp := sacloud.NewInterfaceOp(client)
+	var iface *sacloud.Interface
+	err = shutdownServerWhile(ctx, d, client, zone, serverID, func() error {
+		created, err := interfaceOp.Create(ctx, zone, &sacloud.InterfaceCreateRequest{ServerID: serverID})
+		if err != nil {
+			return err
+		}
+		iface = created
+		return connectServerNetworkInterfaceUpstream(ctx, d, client, zone, iface)
+	})
+	if iface != nil {
+		d.SetId(iface.ID.String())
+	}
+	if err != nil {
+		return diag.Errorf("creating SakuraCloud NetworkInterface is failed: %s", err)
+	}
+
+	if err := updateServerNetworkInterfaceSettings(ctx, d, client, zone, iface); err != nil {
+		return diag.Errorf("creating SakuraCloud NetworkInterface is failed: %s", err)
+	}
+	return resourceSakuraCloudServerNetworkInterfaceRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudServerNetworkInterfaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	interfaceOp := sacloud.NewInterfaceOp(client)
+	iface, err := interfaceOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud NetworkInterface[%s]: %s", d.Id(), err)
+	}
+	return setServerNetworkInterfaceResourceData(ctx, d, client, iface)
+}
+
+func resourceSakuraCloudServerNetworkInterfaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := expandSakuraCloudID(d, "server_id")
+	sakuraMutexKV.Lock(serverID.String())
+	defer sakuraMutexKV.Unlock(serverID.String())
+
+	interfaceOp := sacloud.NewInterfaceOp(client)
+	iface, err := interfaceOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud NetworkInterface[%s]: %s", d.Id(), err)
+	}
+
+	if d.HasChange("upstream") {
+		err := shutdownServerWhile(ctx, d, client, zone, serverID, func() error {
+			if !iface.SwitchID.IsEmpty() {
+				if err := interfaceOp.DisconnectFromSwitch(ctx, zone, iface.ID); err != nil {
+					return err
+				}
+			}
+			return connectServerNetworkInterfaceUpstream(ctx, d, client, zone, iface)
+		})
+		if err != nil {
+			return diag.Errorf("updating SakuraCloud NetworkInterface[%s] is failed: %s", iface.ID, err)
+		}
+	}
+
+	if err := updateServerNetworkInterfaceSettings(ctx, d, client, zone, iface); err != nil {
+		return diag.Errorf("updating SakuraCloud NetworkInterface[%s] is failed: %s", iface.ID, err)
+	}
+	return resourceSakuraCloudServerNetworkInterfaceRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudServerNetworkInterfaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := expandSakuraCloudID(d, "server_id")
+	sakuraMutexKV.Lock(serverID.String())
+	defer sakuraMutexKV.Unlock(serverID.String())
+
+	interfaceOp := sacloud.NewInterfaceOp(client)
+	iface, err := interfaceOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud NetworkInterface[%s]: %s", d.Id(), err)
+	}
+
+	err = shutdownServerWhile(ctx, d, client, zone, serverID, func() error {
+		if !iface.SwitchID.IsEmpty() {
+			if err := interfaceOp.DisconnectFromSwitch(ctx, zone, iface.ID); err != nil {
+				return err
+			}
+		}
+		return interfaceOp.Delete(ctx, zone, iface.ID)
+	})
+	if err != nil {
+		return diag.Errorf("deleting SakuraCloud NetworkInterface[%s] is failed: %s", iface.ID, err)
+	}
+	return nil
+}
+
+func setServerNetworkInterfaceResourceData(_ context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.Interface) diag.Diagnostics {
+	d.Set("server_id", data.ServerID.String())                     // nolint
+	d.Set("upstream", flattenServerNetworkInterfaceUpstream(data)) // nolint
+	d.Set("packet_filter_id", data.PacketFilterID.String())        // nolint
+	d.Set("user_ip_address", data.UserIPAddress)                   // nolint
+	d.Set("mac_address", strings.ToLower(data.MACAddress))         // nolint
+	d.Set("ip_address", data.IPAddress)                            // nolint
+	d.Set("zone", getZone(d, client))                              // nolint
+	return nil
+}
+
+// shutdownServerWhile サーバが起動している場合は停止した上でfnを実行し、再度起動する
+//
+// fnが失敗した場合も停止前に起動していたサーバは起動し、エラーはまとめて返す
+func shutdownServerWhile(ctx context.Context, d resourceValueGettable, client *APIClient, zone string, serverID types.ID, fn func() error) (err error) {
+	serverOp := sacloud.NewServerOp(client)
+	server, err := serverOp.Read(ctx, zone, serverID)
+	if err != nil {
+		return err
+	}
+
+	if server.InstanceStatus.IsUp() {
+		if err := power.ShutdownServer(ctx, serverOp, zone, server.ID, boolOrDefault(d, "force_shutdown")); err != nil {
+			return err
+		}
+		defer func() {
+			if bootErr := power.BootServer(ctx, serverOp, zone, server.ID); bootErr != nil {
+				err = multierror.Append(err, fmt.Errorf("booting SakuraCloud Server[%s] is failed: %s", server.ID, bootErr))
+			}
+		}()
+	}
+
+	return fn()
+}
+
+func connectServerNetworkInterfaceUpstream(ctx context.Context, d resourceValueGettable, client *APIClient, zone string, iface *sacloud.Interface) error {
+	interfaceOp := sacloud.NewInterfaceOp(client)
+	switch upstream := d.Get("upstream").(string); upstream {
+	case "", "disconnect":
+		return nil
+	case "shared":
+		return interfaceOp.ConnectToSharedSegment(ctx, zone, iface.ID)
+	default:
+		return interfaceOp.ConnectToSwitch(ctx, zone, iface.ID, sakuraCloudID(upstream))
+	}
+}
+
+// updateServerNetworkInterfaceSettings パケットフィルタと表示用IPアドレスを更新する(サーバの停止は不要)
+func updateServerNetworkInterfaceSettings(ctx context.Context, d resourceValueGettable, client *APIClient, zone string, iface *sacloud.Interface) error {
+	interfaceOp := sacloud.NewInterfaceOp(client)
+
+	packetFilterID := expandSakuraCloudID(d, "packet_filter_id")
+	if packetFilterID != iface.PacketFilterID {
+		if !iface.PacketFilterID.IsEmpty() {
+			if err := interfaceOp.DisconnectFromPacketFilter(ctx, zone, iface.ID); err != nil {
+				return err
+			}
+		}
+		if !packetFilterID.IsEmpty() {
+			if err := interfaceOp.ConnectToPacketFilter(ctx, zone, iface.ID, packetFilterID); err != nil {
+				return err
+			}
+		}
+	}
+
+	userIPAddress := stringOrDefault(d, "user_ip_address")
+	if userIPAddress != "" && userIPAddress != iface.UserIPAddress {
+		if _, err := interfaceOp.Update(ctx, zone, iface.ID, &sacloud.InterfaceUpdateRequest{UserIPAddress: userIPAddress}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flattenServerNetworkInterfaceUpstream(iface *sacloud.Interface) string {
+	switch {
+	case iface.SwitchID.IsEmpty():
+		return "disconnect"
+	case iface.SwitchScope == types.Scopes.Shared:
+		return "shared"
+	default:
+		return iface.SwitchID.String()
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func TestAccSakuraCloudServerNetworkInterface_basic(t *testing.T) {
+	resourceName := "sakuracloud_server_network_interface.foobar"
+	rand := randomName()
+
+	var iface sacloud.Interface
+	var server sacloud.Server
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudServerNetworkInterfaceDestroy,
+			testCheckSakuraCloudServerDestroy,
+			testCheckSakuraCloudSwitchDestroy,
+			testCheckSakuraCloudPacketFilterDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudServerNetworkInterface_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudServerNetworkInterfaceExists(resourceName, &iface),
+					testCheckSakuraCloudServerExists("sakuracloud_server.foobar", &server),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "server_id",
+						"sakuracloud_server.foobar", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "upstream",
+						"sakuracloud_switch.foobar", "id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "packet_filter_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "user_ip_address", "192.168.0.11"),
+					resource.TestCheckResourceAttrSet(resourceName, "mac_address"),
+					resource.TestCheckResourceAttr("sakuracloud_server.foobar", "network_interface.#", "1"),
+					resource.TestCheckResourceAttr("sakuracloud_server.foobar", "managed_network_interface_count", "1"),
+				),
+			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudServerNetworkInterface_update, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudServerNetworkInterfaceExists(resourceName, &iface),
+					resource.TestCheckResourceAttr(resourceName, "upstream", "disconnect"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "packet_filter_id",
+						"sakuracloud_packet_filter.foobar", "id",
+					),
+					resource.TestCheckResourceAttr("sakuracloud_server.foobar", "network_interface.#", "1"),
+					resource.TestCheckResourceAttr("sakuracloud_server.foobar", "managed_network_interface_count", "1"),
+					resource.TestCheckResourceAttr("sakuracloud_server.foobar", "description", "description-upd"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"force_shutdown",
+				},
+			},
+		},
+	})
+}
+
+func testCheckSakuraCloudServerNetworkInterfaceExists(n string, iface *sacloud.Interface) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("no NetworkInterface ID is set")
+		}
+
+		client := testAccProvider.Meta().(*APIClient)
+		zone := rs.Primary.Attributes["zone"]
+		interfaceOp := sacloud.NewInterfaceOp(client)
+
+		foundInterface, err := interfaceOp.Read(context.Background(), zone, sakuraCloudID(rs.Primary.ID))
+		if err != nil {
+			return err
+		}
+
+		if foundInterface.ID.String() != rs.Primary.ID {
+			return fmt.Errorf("not found NetworkInterface: %s", rs.Primary.ID)
+		}
+
+		*iface = *foundInterface
+		return nil
+	}
+}
+
+func testCheckSakuraCloudServerNetworkInterfaceDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*APIClient)
+	interfaceOp := sacloud.NewInterfaceOp(client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "sakuracloud_server_network_interface" {
+			continue
+		}
+		if rs.Primary.ID == "" {
+			continue
+		}
+
+		zone := rs.Primary.Attributes["zone"]
+		_, err := interfaceOp.Read(context.Background(), zone, sakuraCloudID(rs.Primary.ID))
+		if err == nil {
+			return fmt.Errorf("still exists NetworkInterface: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+var testAccSakuraCloudServerNetworkInterface_basic = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_packet_filter" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name = "{{ .arg0 }}"
+  network_interface {
+    upstream = "shared"
+  }
+  force_shutdown = true
+}
+
+resource "sakuracloud_server_network_interface" "foobar" {
+  server_id       = sakuracloud_server.foobar.id
+  upstream        = sakuracloud_switch.foobar.id
+  user_ip_address = "192.168.0.11"
+  force_shutdown  = true
+}
+`
+
+var testAccSakuraCloudServerNetworkInterface_update = `
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_packet_filter" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_server" "foobar" {
+  name        = "{{ .arg0 }}"
+  description = "description-upd"
+  network_interface {
+    upstream = "shared"
+  }
+  force_shutdown = true
+}
+
+resource "sakuracloud_server_network_interface" "foobar" {
+  server_id        = sakuracloud_server.foobar.id
+  upstream         = "disconnect"
+  packet_filter_id = sakuracloud_packet_filter.foobar.id
+  user_ip_address  = "192.168.0.11"
+  force_shutdown   = true
+}
+`
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -155,6 +156,22 @@ func TestAccSakuraCloudServer_planChange(t *testing.T) {
 	})
 }
 
+func TestAccSakuraCloudServer_invalidPlan(t *testing.T) {
+	rand := randomName()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckSakuraCloudServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      buildConfigWithArgs(testAccSakuraCloudServer_invalidPlan, rand),
+				ExpectError: regexp.MustCompile(`server plan\[core: 200, memory: 1GB, commitment: standard\] is not available`),
+			},
+		},
+	})
+}
+
 func TestAccSakuraCloudServer_withoutShutdown(t *testing.T) {
 	resourceName := "sakuracloud_server.foobar"
 	rand := randomName()
@@ -823,6 +840,14 @@ resource "sakuracloud_server" "foobar" {
 }
 `
 
+const testAccSakuraCloudServer_invalidPlan = `
+resource "sakuracloud_server" "foobar" {
+  name   = "{{ .arg0 }}"
+  core   = 200
+  memory = 1
+}
+`
+
 const testAccSakuraCloudServer_standardPlan = `
 data "sakuracloud_archive" "ubuntu" {
   os_type = "ubuntu2004"
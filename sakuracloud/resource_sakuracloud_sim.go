@@ -34,8 +34,9 @@ func resourceSakuraCloudSIM() *schema.Resource {
 		ReadContext:   resourceSakuraCloudSIMRead,
 		UpdateContext: resourceSakuraCloudSIMUpdate,
 		DeleteContext: resourceSakuraCloudSIMDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudSIMRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -85,6 +86,7 @@ func resourceSakuraCloudSIM() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"mobile_gateway_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -217,5 +219,5 @@ func setSIMResourceData(ctx context.Context, d *schema.ResourceData, client *API
 		return diag.FromErr(err)
 	}
 
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
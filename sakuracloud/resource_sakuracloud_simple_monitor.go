@@ -33,8 +33,9 @@ func resourceSakuraCloudSimpleMonitor() *schema.Resource {
 		ReadContext:   resourceSakuraCloudSimpleMonitorRead,
 		UpdateContext: resourceSakuraCloudSimpleMonitorUpdate,
 		DeleteContext: resourceSakuraCloudSimpleMonitorDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudSimpleMonitorRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -167,6 +168,7 @@ func resourceSakuraCloudSimpleMonitor() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"notify_email_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -215,7 +217,7 @@ func resourceSakuraCloudSimpleMonitorCreate(ctx context.Context, d *schema.Resou
 
 	smOp := sacloud.NewSimpleMonitorOp(client)
 
-	simpleMonitor, err := smOp.Create(ctx, expandSimpleMonitorCreateRequest(d))
+	simpleMonitor, err := smOp.Create(ctx, expandSimpleMonitorCreateRequest(d, client))
 	if err != nil {
 		return diag.Errorf("creating SimpleMonitor is failed: %s", err)
 	}
@@ -257,7 +259,7 @@ func resourceSakuraCloudSimpleMonitorUpdate(ctx context.Context, d *schema.Resou
 		return diag.Errorf("could not read SimpleMonitor[%s]: %s", d.Id(), err)
 	}
 
-	if _, err = smOp.Update(ctx, simpleMonitor.ID, expandSimpleMonitorUpdateRequest(d)); err != nil {
+	if _, err = smOp.Update(ctx, simpleMonitor.ID, expandSimpleMonitorUpdateRequest(d, client)); err != nil {
 		return diag.Errorf("updating SimpleMonitor[%s] is failed: %s", d.Id(), err)
 	}
 
@@ -301,5 +303,5 @@ func setSimpleMonitorResourceData(ctx context.Context, d *schema.ResourceData, c
 	if err := d.Set("health_check", flattenSimpleMonitorHealthCheck(data)); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -31,7 +31,7 @@ func resourceSakuraCloudSSHKey() *schema.Resource {
 		UpdateContext: resourceSakuraCloudSSHKeyUpdate,
 		DeleteContext: resourceSakuraCloudSSHKeyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importGlobalResourceStateContext(resourceName, resourceSakuraCloudSSHKeyRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -32,7 +32,7 @@ func resourceSakuraCloudSubnet() *schema.Resource {
 		UpdateContext: resourceSakuraCloudSubnetUpdate,
 		DeleteContext: resourceSakuraCloudSubnetDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudSubnetRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -108,7 +108,7 @@ func resourceSakuraCloudSubnetCreate(ctx context.Context, d *schema.ResourceData
 	sakuraMutexKV.Lock(internetID)
 	defer sakuraMutexKV.Unlock(internetID)
 
-	internet, err := internetOp.Read(ctx, zone, sakuraCloudID(internetID))
+	internet, err := readInternet(ctx, client, zone, sakuraCloudID(internetID))
 	if err != nil {
 		return diag.Errorf("could not read SakuraCloud Internet[%s]: %s", internetID, err)
 	}
@@ -162,7 +162,8 @@ func resourceSakuraCloudSubnetUpdate(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("could not read Subnet[%s]: %s", d.Id(), err)
 	}
 
-	_, err = internetOp.UpdateSubnet(ctx, zone, sakuraCloudID(internetID), subnet.ID, &sacloud.InternetUpdateSubnetRequest{
+	// スイッチ+ルータの帯域変更でルータのIDが変わっている場合があるため、サブネットが現在接続されているルータを利用する
+	_, err = internetOp.UpdateSubnet(ctx, zone, subnet.InternetID, subnet.ID, &sacloud.InternetUpdateSubnetRequest{
 		NextHop: d.Get("next_hop").(string),
 	})
 	if err != nil {
@@ -194,13 +195,13 @@ func resourceSakuraCloudSubnetDelete(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("could not read Subnet[%s]: %s", d.Id(), err)
 	}
 
-	if err := internetOp.DeleteSubnet(ctx, zone, sakuraCloudID(internetID), subnet.ID); err != nil {
+	if err := internetOp.DeleteSubnet(ctx, zone, subnet.InternetID, subnet.ID); err != nil {
 		return diag.Errorf("deleting Subnet[%s] is failed: %s", subnet.ID, err)
 	}
 	return nil
 }
 
-func setSubnetResourceData(_ context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.Subnet) diag.Diagnostics {
+func setSubnetResourceData(ctx context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.Subnet) diag.Diagnostics {
 	if data.SwitchID.IsEmpty() {
 		return diag.Errorf("error reading SakuraCloud Subnet[%s]: %s", data.ID, "switch is nil")
 	}
@@ -212,8 +213,20 @@ func setSubnetResourceData(_ context.Context, d *schema.ResourceData, client *AP
 		addrs = append(addrs, ip.IPAddress)
 	}
 
+	// 帯域変更後もsakuracloud_internetのリソースIDで参照している場合はその値を維持する
+	internetID := data.InternetID.String()
+	if v := d.Get("internet_id").(string); v != "" && v != internetID {
+		internet, err := readInternet(ctx, client, getZone(d, client), sakuraCloudID(v))
+		if err != nil && !sacloud.IsNotFoundError(err) {
+			return diag.Errorf("could not read SakuraCloud Internet[%s]: %s", v, err)
+		}
+		if err == nil && internet.ID == data.InternetID {
+			internetID = v
+		}
+	}
+
 	d.Set("switch_id", data.SwitchID.String())                                   // nolint
-	d.Set("internet_id", data.InternetID.String())                               // nolint
+	d.Set("internet_id", internetID)                                             // nolint
 	d.Set("netmask", data.NetworkMaskLen)                                        // nolint
 	d.Set("next_hop", data.NextHop)                                              // nolint
 	d.Set("network_address", data.NetworkAddress)                                // nolint
@@ -52,6 +52,14 @@ func TestAccSakuraCloudSubnet_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "ip_addresses.#", "16"),
 				),
 			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudSubnet_updateBandWidth, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudSubnetExists(resourceName, &subnet),
+					resource.TestCheckResourceAttrPair(resourceName, "internet_id", "sakuracloud_internet.foobar", "id"),
+					resource.TestCheckResourceAttr(resourceName, "ip_addresses.#", "16"),
+				),
+			},
 		},
 	})
 }
@@ -124,3 +132,13 @@ resource "sakuracloud_subnet" "foobar" {
   internet_id = sakuracloud_internet.foobar.id
   next_hop    = sakuracloud_internet.foobar.max_ip_address
 }`
+
+var testAccSakuraCloudSubnet_updateBandWidth = `
+resource sakuracloud_internet "foobar" {
+  name       = "{{ .arg0 }}"
+  band_width = 500
+}
+resource "sakuracloud_subnet" "foobar" {
+  internet_id = sakuracloud_internet.foobar.id
+  next_hop    = sakuracloud_internet.foobar.max_ip_address
+}`
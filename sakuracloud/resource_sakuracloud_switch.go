@@ -32,8 +32,9 @@ func resourceSakuraCloudSwitch() *schema.Resource {
 		ReadContext:   resourceSakuraCloudSwitchRead,
 		UpdateContext: resourceSakuraCloudSwitchUpdate,
 		DeleteContext: resourceSakuraCloudSwitchDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudSwitchRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -47,9 +48,11 @@ func resourceSakuraCloudSwitch() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"bridge_id": {
 				Type:             schema.TypeString,
 				Optional:         true,
+				Computed:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
 				Description:      descf("The bridge id attached to the %s", resourceName),
 			},
@@ -74,7 +77,7 @@ func resourceSakuraCloudSwitchCreate(ctx context.Context, d *schema.ResourceData
 	req := &sacloud.SwitchCreateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 
@@ -132,7 +135,7 @@ func resourceSakuraCloudSwitchUpdate(ctx context.Context, d *schema.ResourceData
 	req := &sacloud.SwitchUpdateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 
@@ -213,5 +216,5 @@ func setSwitchResourceData(ctx context.Context, d *schema.ResourceData, client *
 	if err := d.Set("server_ids", serverIDs); err != nil {
 		return diag.FromErr(err)
 	}
-	return diag.FromErr(d.Set("tags", flattenTags(data.Tags)))
+	return diag.FromErr(setResourceTags(d, client, data.Tags))
 }
@@ -0,0 +1,148 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func resourceSakuraCloudSwitchBridgeConnection() *schema.Resource {
+	resourceName := "Switch Bridge Connection"
+	return &schema.Resource{
+		CreateContext: resourceSakuraCloudSwitchBridgeConnectionCreate,
+		ReadContext:   resourceSakuraCloudSwitchBridgeConnectionRead,
+		DeleteContext: resourceSakuraCloudSwitchBridgeConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudSwitchBridgeConnectionRead),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"switch_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Switch to connect to the Bridge",
+			},
+			"bridge_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateSakuracloudIDType),
+				Description:      "The id of the Bridge to which the Switch is connected",
+			},
+			"zone": schemaResourceZone(resourceName),
+		},
+	}
+}
+
+func resourceSakuraCloudSwitchBridgeConnectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	swID := d.Get("switch_id").(string)
+	brID := d.Get("bridge_id").(string)
+
+	sakuraMutexKV.Lock(swID)
+	defer sakuraMutexKV.Unlock(swID)
+
+	swOp := sacloud.NewSwitchOp(client)
+	sw, err := swOp.Read(ctx, zone, sakuraCloudID(swID))
+	if err != nil {
+		return diag.Errorf("could not read SakuraCloud Switch[%s]: %s", swID, err)
+	}
+
+	switch {
+	case sw.BridgeID.IsEmpty():
+		if err := swOp.ConnectToBridge(ctx, zone, sw.ID, sakuraCloudID(brID)); err != nil {
+			return diag.Errorf("connecting Switch[%s] to Bridge[%s] is failed: %s", sw.ID, brID, err)
+		}
+	case sw.BridgeID.String() != brID:
+		return diag.Errorf("connecting Switch[%s] to Bridge[%s] is failed: Switch is already connected to Bridge[%s]", sw.ID, brID, sw.BridgeID)
+	}
+
+	d.SetId(sw.ID.String())
+	return resourceSakuraCloudSwitchBridgeConnectionRead(ctx, d, meta)
+}
+
+func resourceSakuraCloudSwitchBridgeConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sw, err := sacloud.NewSwitchOp(client).Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud Switch[%s]: %s", d.Id(), err)
+	}
+	// ブリッジから切断されている場合は再作成させる
+	if sw.BridgeID.IsEmpty() {
+		d.SetId("")
+		return nil
+	}
+	return setSwitchBridgeConnectionResourceData(ctx, d, client, sw)
+}
+
+func resourceSakuraCloudSwitchBridgeConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, zone, err := sakuraCloudClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sakuraMutexKV.Lock(d.Id())
+	defer sakuraMutexKV.Unlock(d.Id())
+
+	swOp := sacloud.NewSwitchOp(client)
+	sw, err := swOp.Read(ctx, zone, sakuraCloudID(d.Id()))
+	if err != nil {
+		if sacloud.IsNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("could not read SakuraCloud Switch[%s]: %s", d.Id(), err)
+	}
+
+	if !sw.BridgeID.IsEmpty() && sw.BridgeID.String() == d.Get("bridge_id").(string) {
+		if err := swOp.DisconnectFromBridge(ctx, zone, sw.ID); err != nil {
+			return diag.Errorf("disconnecting Switch[%s] from Bridge[%s] is failed: %s", sw.ID, sw.BridgeID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func setSwitchBridgeConnectionResourceData(_ context.Context, d *schema.ResourceData, client *APIClient, data *sacloud.Switch) diag.Diagnostics {
+	d.Set("switch_id", data.ID.String())       // nolint
+	d.Set("bridge_id", data.BridgeID.String()) // nolint
+	d.Set("zone", getZone(d, client))          // nolint
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func TestAccSakuraCloudSwitchBridgeConnection_basic(t *testing.T) {
+	resourceName := "sakuracloud_switch_bridge_connection.foobar"
+	rand := randomName()
+
+	var sw sacloud.Switch
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSakuraCloudSwitchDestroy,
+			testCheckSakuraCloudBridgeDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudSwitchBridgeConnection_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudSwitchExists("sakuracloud_switch.foobar", &sw),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "switch_id",
+						"sakuracloud_switch.foobar", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "bridge_id",
+						"sakuracloud_bridge.foobar", "id",
+					),
+				),
+			},
+			{
+				// sakuracloud_switchのbridge_id(Computed)へ接続状態が反映されていること
+				Config: buildConfigWithArgs(testAccSakuraCloudSwitchBridgeConnection_basic, rand),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"sakuracloud_switch.foobar", "bridge_id",
+						"sakuracloud_bridge.foobar", "id",
+					),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", resourceName)
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["zone"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+var testAccSakuraCloudSwitchBridgeConnection_basic = `
+resource "sakuracloud_bridge" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+}
+
+resource "sakuracloud_switch_bridge_connection" "foobar" {
+  switch_id = sakuracloud_switch.foobar.id
+  bridge_id = sakuracloud_bridge.foobar.id
+}
+`
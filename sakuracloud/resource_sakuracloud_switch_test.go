@@ -18,11 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
 )
 
 func TestAccSakuraCloudSwitch_basic(t *testing.T) {
@@ -77,6 +79,96 @@ func TestAccSakuraCloudSwitch_basic(t *testing.T) {
 	})
 }
 
+func TestAccSakuraCloudSwitch_defaultTags(t *testing.T) {
+	resourceName := "sakuracloud_switch.foobar"
+	rand := randomName()
+
+	var sw sacloud.Switch
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckSakuraCloudSwitchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudSwitch_defaultTags, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudSwitchExists(resourceName, &sw),
+					func(state *terraform.State) error {
+						expected := types.Tags{"env=test", "tag1", "team=x"}
+						actual := append(types.Tags{}, sw.Tags...)
+						actual.Sort()
+						if !reflect.DeepEqual(actual, expected) {
+							return fmt.Errorf("unexpected tags: expected: %v actual: %v", expected, actual)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.0", "env=test"),
+					resource.TestCheckResourceAttr(resourceName, "tags.1", "tag1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.2", "team=x"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSakuraCloudSwitch_ignoreTags(t *testing.T) {
+	resourceName := "sakuracloud_switch.foobar"
+	rand := randomName()
+
+	var sw sacloud.Switch
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testCheckSakuraCloudSwitchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudSwitch_ignoreTags, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudSwitchExists(resourceName, &sw),
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "1"),
+				),
+			},
+			{
+				// 外部ツールによるタグの付与
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*APIClient)
+					swOp := sacloud.NewSwitchOp(client)
+					_, err := swOp.Update(context.Background(), client.defaultZone, sw.ID, &sacloud.SwitchUpdateRequest{
+						Name:        sw.Name,
+						Description: sw.Description,
+						Tags:        append(sw.Tags, "@auto-reboot", "managed-by=x"),
+						IconID:      sw.IconID,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   buildConfigWithArgs(testAccSakuraCloudSwitch_ignoreTags, rand),
+				PlanOnly: true,
+			},
+			{
+				Config: buildConfigWithArgs(testAccSakuraCloudSwitch_ignoreTagsUpdate, rand),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSakuraCloudSwitchExists(resourceName, &sw),
+					func(state *terraform.State) error {
+						expected := types.Tags{"@auto-reboot", "managed-by=x", "tag1-upd"}
+						actual := append(types.Tags{}, sw.Tags...)
+						actual.Sort()
+						if !reflect.DeepEqual(actual, expected) {
+							return fmt.Errorf("unexpected tags: expected: %v actual: %v", expected, actual)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.0", "tag1-upd"),
+				),
+			},
+		},
+	})
+}
+
 func testCheckSakuraCloudSwitchExists(n string, sw *sacloud.Switch) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -129,6 +221,45 @@ func testCheckSakuraCloudSwitchDestroy(s *terraform.State) error {
 	return nil
 }
 
+var testAccSakuraCloudSwitch_defaultTags = `
+provider "sakuracloud" {
+  default_tags = ["env=test", "team=x"]
+}
+
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+  tags = ["tag1", "env=test"]
+}
+`
+
+var testAccSakuraCloudSwitch_ignoreTags = `
+provider "sakuracloud" {
+  ignore_tags {
+    keys         = ["managed-by"]
+    key_prefixes = ["@auto-"]
+  }
+}
+
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+  tags = ["tag1"]
+}
+`
+
+var testAccSakuraCloudSwitch_ignoreTagsUpdate = `
+provider "sakuracloud" {
+  ignore_tags {
+    keys         = ["managed-by"]
+    key_prefixes = ["@auto-"]
+  }
+}
+
+resource "sakuracloud_switch" "foobar" {
+  name = "{{ .arg0 }}"
+  tags = ["tag1-upd"]
+}
+`
+
 var testAccSakuraCloudSwitch_basic = `
 resource "sakuracloud_switch" "foobar" {
   name        = "{{ .arg0 }}"
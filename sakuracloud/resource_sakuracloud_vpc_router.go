@@ -33,8 +33,9 @@ func resourceSakuraCloudVPCRouter() *schema.Resource {
 		ReadContext:   resourceSakuraCloudVPCRouterRead,
 		UpdateContext: resourceSakuraCloudVPCRouterUpdate,
 		DeleteContext: resourceSakuraCloudVPCRouterDelete,
+		CustomizeDiff: customizeDiffTagsAll,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importZonedResourceStateContext(resourceName, resourceSakuraCloudVPCRouterRead),
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -523,6 +524,7 @@ func resourceSakuraCloudVPCRouter() *schema.Resource {
 			"icon_id":     schemaResourceIconID(resourceName),
 			"description": schemaResourceDescription(resourceName),
 			"tags":        schemaResourceTags(resourceName),
+			"tags_all":    schemaResourceTagsAll(resourceName),
 			"zone":        schemaResourceZone(resourceName),
 			"public_ip": {
 				Type:        schema.TypeString,
@@ -656,7 +658,7 @@ func setVPCRouterResourceData(ctx context.Context, d *schema.ResourceData, zone
 	d.Set("name", data.Name)               // nolint
 	d.Set("icon_id", data.IconID.String()) // nolint
 	d.Set("description", data.Description) // nolint
-	if err := d.Set("tags", flattenTags(data.Tags)); err != nil {
+	if err := setResourceTags(d, client, data.Tags); err != nil {
 		return diag.FromErr(err)
 	}
 	d.Set("plan", flattenVPCRouterPlan(data))                           // nolint
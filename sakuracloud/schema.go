@@ -123,6 +123,16 @@ func schemaResourceTags(resourceName string) *schema.Schema {
 	}
 }
 
+func schemaResourceTagsAll(resourceName string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+		Description: descf("A set of tags assigned to the %s, including the `default_tags` of the provider", resourceName),
+	}
+}
+
 func schemaDataSourceZone(resourceName string) *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeString,
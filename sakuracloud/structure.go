@@ -16,16 +16,18 @@ package sakuracloud
 
 import (
 	"bytes"
+	"context"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mitchellh/go-homedir"
 	"github.com/sacloud/libsacloud/v2/sacloud/search"
 	"github.com/sacloud/libsacloud/v2/sacloud/search/keys"
@@ -140,8 +142,8 @@ func mapFromFirstElement(d resourceValueGettable, key string) resourceValueGetta
 func sakuraCloudClient(d resourceValueGettable, meta interface{}) (*APIClient, string, error) {
 	client := meta.(*APIClient)
 	zone := getZone(d, client)
-	if _, errs := validation.StringInSlice(client.zones, false)(zone, "zone"); len(errs) > 0 {
-		return nil, "", errs[0]
+	if err := client.validateZone("zone", zone); err != nil {
+		return nil, "", err
 	}
 
 	return client, zone, nil
@@ -157,6 +159,55 @@ func getZone(d resourceValueGettable, client *APIClient) string {
 	return client.defaultZone
 }
 
+// importZonedResourceStateContext "<zone>/<id>"または"<zone>:<id>"形式のIDでのインポートに対応したStateContextFuncを返す
+//
+// ゾーンが含まれない場合はschema.ImportStatePassthroughContextと同じくIDをそのまま利用する
+func importZonedResourceStateContext(resourceName string, read schema.ReadContextFunc) schema.StateContextFunc {
+	return importResourceStateContext(resourceName, read, true)
+}
+
+// importGlobalResourceStateContext グローバルリソース向けのimportZonedResourceStateContext
+//
+// IDに含まれるゾーンは検証のみ行い、リソースには設定しない
+func importGlobalResourceStateContext(resourceName string, read schema.ReadContextFunc) schema.StateContextFunc {
+	return importResourceStateContext(resourceName, read, false)
+}
+
+func importResourceStateContext(resourceName string, read schema.ReadContextFunc, zoned bool) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		zone, id, ok := parseZonedID(d.Id())
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		client := meta.(*APIClient)
+		if err := client.validateZone("zone", zone); err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		if zoned {
+			d.Set("zone", zone) // nolint
+		}
+
+		if diags := read(ctx, d, meta); diags.HasError() {
+			return nil, fmt.Errorf("could not read SakuraCloud %s[%s] in zone %q: %s", resourceName, id, zone, diags[0].Summary)
+		}
+		if d.Id() == "" {
+			return nil, fmt.Errorf("SakuraCloud %s[%s] is not found in zone %q", resourceName, id, zone)
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// parseZonedID "<zone>/<id>"または"<zone>:<id>"形式のIDをゾーンとIDに分割する
+func parseZonedID(id string) (string, string, bool) {
+	i := strings.IndexAny(id, "/:")
+	if i <= 0 || i == len(id)-1 {
+		return "", id, false
+	}
+	return id[:i], id[i+1:], true
+}
+
 func sakuraCloudID(id string) types.ID {
 	return types.StringID(id)
 }
@@ -194,22 +245,111 @@ func expandStringList(configured []interface{}) []string {
 	return vs
 }
 
-func expandTags(d resourceValueGettable) types.Tags {
-	var tags []string
-	rawTags := d.Get("tags").(*schema.Set).List()
-	for _, v := range rawTags {
-		v := v.(string)
-		if v != "" {
-			tags = append(tags, v)
+// expandTags リソースに付与するタグを返す
+//
+// tagsとプロバイダーのdefault_tagsをマージし、現在のtags_allのうちignore_tagsに該当するタグを維持する
+func expandTags(d resourceValueGettable, client *APIClient) types.Tags {
+	tags := types.Tags(expandStringList(d.Get("tags").(*schema.Set).List()))
+	current := types.Tags(expandStringList(d.Get("tags_all").(*schema.Set).List()))
+	return expandTagsAll(tags, current, client)
+}
+
+// expandTagsAll tagsとプロバイダーのdefault_tags、currentのうちignore_tagsに該当するタグをマージしたタグを返す
+func expandTagsAll(tags, current types.Tags, client *APIClient) types.Tags {
+	tags = append(types.Tags{}, tags...)
+	for _, t := range current {
+		if client.ignoreTags.isIgnored(t) {
+			tags = append(tags, t)
 		}
 	}
-	return types.Tags(tags)
+	return mergeDefaultTags(tags, client.defaultTags)
 }
 
 func flattenTags(tags types.Tags) *schema.Set {
 	return stringListToSet(tags)
 }
 
+// mergeDefaultTags tagsにプロバイダーのdefault_tagsをマージしたタグを返す
+func mergeDefaultTags(tags, defaultTags types.Tags) types.Tags {
+	merged := types.Tags{}
+	for _, t := range append(append(types.Tags{}, tags...), defaultTags...) {
+		if t != "" && !isTagContained(merged, t) {
+			merged = append(merged, t)
+		}
+	}
+	merged.Sort()
+	return merged
+}
+
+// excludeDefaultTags リソースから読み込んだタグからプロバイダーのdefault_tagsを除外したタグを返す
+//
+// configuredTagsに含まれるタグ(tagsに明示的に指定されたタグ)は除外しない
+func excludeDefaultTags(tags, defaultTags, configuredTags types.Tags) types.Tags {
+	var results types.Tags
+	for _, t := range tags {
+		if isTagContained(defaultTags, t) && !isTagContained(configuredTags, t) {
+			continue
+		}
+		results = append(results, t)
+	}
+	return results
+}
+
+func isTagContained(tags types.Tags, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// excludeIgnoredTags リソースから読み込んだタグからプロバイダーのignore_tagsに該当するタグを除外したタグを返す
+//
+// configuredTagsに含まれるタグ(tagsに明示的に指定されたタグ)は除外しない
+func excludeIgnoredTags(tags types.Tags, ignore *ignoreTags, configuredTags types.Tags) types.Tags {
+	var results types.Tags
+	for _, t := range tags {
+		if ignore.isIgnored(t) && !isTagContained(configuredTags, t) {
+			continue
+		}
+		results = append(results, t)
+	}
+	return results
+}
+
+// setResourceTags tagsとtags_allを設定する
+//
+// tags_allにはignore_tagsに該当するタグも含め、更新時にそのまま書き戻すことで外部ツールが付与したタグを維持する
+func setResourceTags(d *schema.ResourceData, client *APIClient, tags types.Tags) error {
+	configured := expandStringList(d.Get("tags").(*schema.Set).List())
+	if err := d.Set("tags_all", flattenTags(tags)); err != nil {
+		return err
+	}
+	tags = excludeDefaultTags(tags, client.defaultTags, configured)
+	tags = excludeIgnoredTags(tags, client.ignoreTags, configured)
+	return d.Set("tags", flattenTags(tags))
+}
+
+// customizeDiffTagsAll tagsとプロバイダーのdefault_tagsをマージした値をtags_allに設定する
+//
+// 現在のtags_allのうちignore_tagsに該当するタグは維持する
+func customizeDiffTagsAll(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+	client := meta.(*APIClient)
+	tags := types.Tags(expandStringList(d.Get("tags").(*schema.Set).List()))
+	current := types.Tags(expandStringList(d.Get("tags_all").(*schema.Set).List()))
+	merged := expandTagsAll(tags, current, client)
+
+	current.Sort()
+	if d.Id() != "" && reflect.DeepEqual(current, merged) {
+		return nil
+	}
+	return d.SetNew("tags_all", flattenTags(merged))
+}
+
 func expandSubjectAltNames(d resourceValueGettable) []string {
 	var names []string
 	rawNames := d.Get("subject_alt_names").(*schema.Set).List()
@@ -307,6 +447,9 @@ func expandSearchFilter(rawFilters interface{}) search.Filter {
 	if rawConditions, ok := mv["condition"]; ok {
 		for _, rawCondition := range rawConditions.([]interface{}) {
 			mv := rawCondition.(map[string]interface{})
+			if isClientSideFilterCondition(mv) {
+				continue
+			}
 
 			keyName := mv["name"].(string)
 			values := mv["values"].([]interface{})
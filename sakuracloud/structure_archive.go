@@ -18,8 +18,6 @@ import (
 	"io"
 	"os"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	archiveUtil "github.com/sacloud/libsacloud/v2/helper/builder/archive"
 	"github.com/sacloud/libsacloud/v2/sacloud"
@@ -43,8 +41,8 @@ func expandArchiveBuilder(d *schema.ResourceData, zone string, client *APIClient
 
 	sourceArchiveZone := stringOrDefault(d, "source_archive_zone")
 	if sourceArchiveZone != "" {
-		if _, errs := validation.StringInSlice(client.zones, false)(sourceArchiveZone, "source_archive_zone"); len(errs) > 0 {
-			return nil, nil, errs[0]
+		if err := client.validateZone("source_archive_zone", sourceArchiveZone); err != nil {
+			return nil, nil, err
 		}
 		if zone == sourceArchiveZone {
 			sourceArchiveZone = ""
@@ -54,7 +52,7 @@ func expandArchiveBuilder(d *schema.ResourceData, zone string, client *APIClient
 	director := &archiveUtil.Director{
 		Name:              d.Get("name").(string),
 		Description:       d.Get("description").(string),
-		Tags:              expandTags(d),
+		Tags:              expandTags(d, client),
 		IconID:            expandSakuraCloudID(d, "icon_id"),
 		SizeGB:            intOrDefault(d, "size"),
 		SourceReader:      reader,
@@ -90,11 +88,11 @@ func expandArchiveHash(d *schema.ResourceData) string {
 	return hash
 }
 
-func expandArchiveUpdateRequest(d *schema.ResourceData) *sacloud.ArchiveUpdateRequest {
+func expandArchiveUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.ArchiveUpdateRequest {
 	return &sacloud.ArchiveUpdateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 }
@@ -19,11 +19,11 @@ import (
 	"github.com/sacloud/libsacloud/v2/sacloud"
 )
 
-func expandAutoBackupCreateRequest(d *schema.ResourceData) *sacloud.AutoBackupCreateRequest {
+func expandAutoBackupCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.AutoBackupCreateRequest {
 	return &sacloud.AutoBackupCreateRequest{
 		Name:                    d.Get("name").(string),
 		Description:             d.Get("description").(string),
-		Tags:                    expandTags(d),
+		Tags:                    expandTags(d, client),
 		DiskID:                  expandSakuraCloudID(d, "disk_id"),
 		MaximumNumberOfArchives: d.Get("max_backup_num").(int),
 		BackupSpanWeekdays:      expandBackupWeekdays(d, "weekdays"),
@@ -31,11 +31,11 @@ func expandAutoBackupCreateRequest(d *schema.ResourceData) *sacloud.AutoBackupCr
 	}
 }
 
-func expandAutoBackupUpdateRequest(d *schema.ResourceData, autoBackup *sacloud.AutoBackup) *sacloud.AutoBackupUpdateRequest {
+func expandAutoBackupUpdateRequest(d *schema.ResourceData, client *APIClient, autoBackup *sacloud.AutoBackup) *sacloud.AutoBackupUpdateRequest {
 	return &sacloud.AutoBackupUpdateRequest{
 		Name:                    d.Get("name").(string),
 		Description:             d.Get("description").(string),
-		Tags:                    expandTags(d),
+		Tags:                    expandTags(d, client),
 		MaximumNumberOfArchives: d.Get("max_backup_num").(int),
 		BackupSpanWeekdays:      expandBackupWeekdays(d, "weekdays"),
 		IconID:                  expandSakuraCloudID(d, "icon_id"),
@@ -38,21 +38,21 @@ func expandCDROMContentHash(d *schema.ResourceData) string {
 	return ""
 }
 
-func expandCDROMCreateRequest(d *schema.ResourceData) *sacloud.CDROMCreateRequest {
+func expandCDROMCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.CDROMCreateRequest {
 	return &sacloud.CDROMCreateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		SizeMB:      d.Get("size").(int) * size.GiB,
 		IconID:      expandSakuraCloudID(d, "icon_id"),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 	}
 }
 
-func expandCDROMUpdateRequest(d *schema.ResourceData) *sacloud.CDROMUpdateRequest {
+func expandCDROMUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.CDROMUpdateRequest {
 	return &sacloud.CDROMUpdateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 }
@@ -25,7 +25,7 @@ func expandContainerRegistryBuilder(d *schema.ResourceData, client *APIClient, s
 	return &registryUtil.Builder{
 		Name:           d.Get("name").(string),
 		Description:    d.Get("description").(string),
-		Tags:           expandTags(d),
+		Tags:           expandTags(d, client),
 		IconID:         expandSakuraCloudID(d, "icon_id"),
 		AccessLevel:    types.EContainerRegistryAccessLevel(d.Get("access_level").(string)),
 		VirtualDomain:  stringOrDefault(d, "virtual_domain"),
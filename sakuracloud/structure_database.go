@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	databaseBuilder "github.com/sacloud/libsacloud/v2/helper/builder/database"
@@ -35,6 +36,11 @@ func expandDatabaseBuilder(d *schema.ResourceData, client *APIClient) *databaseB
 		dbVersion = types.RDBMSVersions[types.RDBMSTypesMariaDB]
 	}
 
+	version, revision := dbVersion.Version, dbVersion.Revision
+	if v, ok := d.GetOk("database_version"); ok && v.(string) != version {
+		version, revision = v.(string), ""
+	}
+
 	nic := expandDatabaseNetworkInterface(d)
 
 	replicaUser := d.Get("replica_user").(string)
@@ -48,8 +54,8 @@ func expandDatabaseBuilder(d *schema.ResourceData, client *APIClient) *databaseB
 		DefaultRoute:   nic.gateway,
 		Conf: &sacloud.DatabaseRemarkDBConfCommon{
 			DatabaseName:     dbVersion.Name,
-			DatabaseVersion:  dbVersion.Version,
-			DatabaseRevision: dbVersion.Revision,
+			DatabaseVersion:  version,
+			DatabaseRevision: revision,
 			DefaultUser:      d.Get("username").(string),
 			UserPassword:     d.Get("password").(string),
 		},
@@ -63,7 +69,7 @@ func expandDatabaseBuilder(d *schema.ResourceData, client *APIClient) *databaseB
 		},
 		Name:               d.Get("name").(string),
 		Description:        d.Get("description").(string),
-		Tags:               expandTags(d),
+		Tags:               expandTags(d, client),
 		IconID:             expandSakuraCloudID(d, "icon_id"),
 		Client:             databaseBuilder.NewAPIClient(client),
 		BackupSetting:      expandDatabaseBackupSetting(d),
@@ -111,7 +117,7 @@ func expandDatabaseReadReplicaBuilder(ctx context.Context, d *schema.ResourceDat
 	return &databaseBuilder.Builder{
 		Name:           d.Get("name").(string),
 		Description:    d.Get("description").(string),
-		Tags:           expandTags(d),
+		Tags:           expandTags(d, client),
 		IconID:         expandSakuraCloudID(d, "icon_id"),
 		PlanID:         types.ID(masterDB.PlanID.Int64() + 1),
 		SwitchID:       sakuraCloudID(switchID),
@@ -150,14 +156,15 @@ func flattenDatabaseType(db *sacloud.Database) string {
 	return databaseType
 }
 
-func flattenDatabaseTags(db *sacloud.Database) *schema.Set {
+// filterDatabaseTags データベースの種別を表すタグ(@MariaDB-xxx/@postgres-xxx)を除外したタグを返す
+func filterDatabaseTags(db *sacloud.Database) types.Tags {
 	var tags types.Tags
 	for _, t := range db.Tags {
 		if !(strings.HasPrefix(t, "@MariaDB-") || strings.HasPrefix(t, "@postgres-")) {
 			tags = append(tags, t)
 		}
 	}
-	return flattenTags(tags)
+	return tags
 }
 
 func expandDatabaseBackupSetting(d resourceValueGettable) *sacloud.DatabaseSettingBackup {
@@ -234,3 +241,24 @@ func flattenDatabaseReadReplicaNetworkInterface(db *sacloud.Database) []interfac
 		},
 	}
 }
+
+func flattenDatabaseBackupTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func flattenDatabaseBackupHistories(histories []*sacloud.DatabaseBackupHistory) []interface{} {
+	var results []interface{}
+	for _, h := range histories {
+		results = append(results, map[string]interface{}{
+			"id":           databaseBackupID(h),
+			"created_at":   flattenDatabaseBackupTime(h.CreatedAt),
+			"recovered_at": flattenDatabaseBackupTime(h.RecoveredAt),
+			"availability": h.Availability,
+			"size":         h.Size,
+		})
+	}
+	return results
+}
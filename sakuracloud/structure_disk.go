@@ -16,6 +16,7 @@ package sakuracloud
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	diskBuilder "github.com/sacloud/libsacloud/v2/helper/builder/disk"
 	"github.com/sacloud/libsacloud/v2/pkg/size"
 	"github.com/sacloud/libsacloud/v2/sacloud"
 	"github.com/sacloud/libsacloud/v2/sacloud/types"
@@ -33,7 +34,7 @@ func expandDiskPlan(d *schema.ResourceData) types.ID {
 	return types.DiskPlanIDMap[d.Get("plan").(string)]
 }
 
-func expandDiskCreateRequest(d *schema.ResourceData) *sacloud.DiskCreateRequest {
+func expandDiskCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.DiskCreateRequest {
 	return &sacloud.DiskCreateRequest{
 		DiskPlanID:      expandDiskPlan(d),
 		Connection:      types.EDiskConnection(d.Get("connector").(string)),
@@ -42,17 +43,54 @@ func expandDiskCreateRequest(d *schema.ResourceData) *sacloud.DiskCreateRequest
 		SizeMB:          d.Get("size").(int) * size.GiB,
 		Name:            d.Get("name").(string),
 		Description:     d.Get("description").(string),
-		Tags:            expandTags(d),
+		Tags:            expandTags(d, client),
 		IconID:          expandSakuraCloudID(d, "icon_id"),
 	}
 }
 
-func expandDiskUpdateRequest(d *schema.ResourceData) *sacloud.DiskUpdateRequest {
+func expandDiskUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.DiskUpdateRequest {
 	return &sacloud.DiskUpdateRequest{
 		Connection:  types.EDiskConnection(d.Get("connector").(string)),
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 }
+
+func expandDiskEditParameter(d resourceValueGettable) *diskBuilder.UnixEditRequest {
+	return &diskBuilder.UnixEditRequest{
+		HostName:            stringOrDefault(d, "hostname"),
+		Password:            stringOrDefault(d, "password"),
+		DisablePWAuth:       boolOrDefault(d, "disable_pw_auth"),
+		EnableDHCP:          boolOrDefault(d, "enable_dhcp"),
+		ChangePartitionUUID: boolOrDefault(d, "change_partition_uuid"),
+		IPAddress:           stringOrDefault(d, "ip_address"),
+		NetworkMaskLen:      intOrDefault(d, "netmask"),
+		DefaultRoute:        stringOrDefault(d, "gateway"),
+		SSHKeys:             stringListOrDefault(d, "ssh_keys"),
+		SSHKeyIDs:           expandSakuraCloudIDs(d, "ssh_key_ids"),
+		Notes:               expandDiskEditNotes(d),
+	}
+}
+
+func expandDiskEditNotes(d resourceValueGettable) []*sacloud.DiskEditNote {
+	var notes []*sacloud.DiskEditNote
+	if _, ok := d.GetOk("note_ids"); ok {
+		ids := expandSakuraCloudIDs(d, "note_ids")
+		for _, id := range ids {
+			notes = append(notes, &sacloud.DiskEditNote{ID: id})
+		}
+	}
+	if values, ok := d.GetOk("note"); ok { // nolint
+		for _, value := range values.([]interface{}) {
+			d = mapToResourceData(value.(map[string]interface{}))
+			notes = append(notes, &sacloud.DiskEditNote{
+				ID:        expandSakuraCloudID(d, "id"),
+				APIKeyID:  expandSakuraCloudID(d, "api_key_id"),
+				Variables: d.Get("variables").(map[string]interface{}),
+			})
+		}
+	}
+	return notes
+}
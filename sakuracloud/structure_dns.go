@@ -23,24 +23,24 @@ import (
 	"github.com/sacloud/libsacloud/v2/sacloud/types"
 )
 
-func expandDNSCreateRequest(d *schema.ResourceData) *sacloud.DNSCreateRequest {
+func expandDNSCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.DNSCreateRequest {
 	return &sacloud.DNSCreateRequest{
 		Name:        d.Get("zone").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 		Records:     expandDNSRecords(d, "record"),
 	}
 }
 
-func expandDNSUpdateRequest(d *schema.ResourceData, dns *sacloud.DNS) *sacloud.DNSUpdateRequest {
+func expandDNSUpdateRequest(d *schema.ResourceData, client *APIClient, dns *sacloud.DNS) *sacloud.DNSUpdateRequest {
 	records := dns.Records
 	if d.HasChange("record") {
 		records = expandDNSRecords(d, "record")
 	}
 	return &sacloud.DNSUpdateRequest{
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 		Records:     records,
 	}
@@ -19,20 +19,20 @@ import (
 	"github.com/sacloud/libsacloud/v2/sacloud"
 )
 
-func expandESMECreateRequest(d *schema.ResourceData) *sacloud.ESMECreateRequest {
+func expandESMECreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.ESMECreateRequest {
 	return &sacloud.ESMECreateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 }
 
-func expandESMEUpdateRequest(d *schema.ResourceData, autoBackup *sacloud.ESME) *sacloud.ESMEUpdateRequest {
+func expandESMEUpdateRequest(d *schema.ResourceData, client *APIClient, autoBackup *sacloud.ESME) *sacloud.ESMEUpdateRequest {
 	return &sacloud.ESMEUpdateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 }
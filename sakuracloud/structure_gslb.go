@@ -110,7 +110,7 @@ func expandGSLBServer(d resourceValueGettable) *sacloud.GSLBServer {
 	}
 }
 
-func expandGSLBCreateRequest(d *schema.ResourceData) *sacloud.GSLBCreateRequest {
+func expandGSLBCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.GSLBCreateRequest {
 	return &sacloud.GSLBCreateRequest{
 		HealthCheck:        expandGSLBHealthCheckConf(d),
 		DelayLoop:          expandGSLBDelayLoop(d),
@@ -119,16 +119,16 @@ func expandGSLBCreateRequest(d *schema.ResourceData) *sacloud.GSLBCreateRequest
 		DestinationServers: expandGSLBServers(d),
 		Name:               d.Get("name").(string),
 		Description:        d.Get("description").(string),
-		Tags:               expandTags(d),
+		Tags:               expandTags(d, client),
 		IconID:             expandSakuraCloudID(d, "icon_id"),
 	}
 }
 
-func expandGSLBUpdateRequest(d *schema.ResourceData, gslb *sacloud.GSLB) *sacloud.GSLBUpdateRequest {
+func expandGSLBUpdateRequest(d *schema.ResourceData, client *APIClient, gslb *sacloud.GSLB) *sacloud.GSLBUpdateRequest {
 	return &sacloud.GSLBUpdateRequest{
 		Name:               d.Get("name").(string),
 		Description:        d.Get("description").(string),
-		Tags:               expandTags(d),
+		Tags:               expandTags(d, client),
 		IconID:             expandSakuraCloudID(d, "icon_id"),
 		HealthCheck:        expandGSLBHealthCheckConf(d),
 		DelayLoop:          expandGSLBDelayLoop(d),
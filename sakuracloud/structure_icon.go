@@ -50,21 +50,21 @@ func expandIconBody(d resourceValueGettable) (string, error) {
 	return body, nil
 }
 
-func expandIconCreateRequest(d *schema.ResourceData) (*sacloud.IconCreateRequest, error) {
+func expandIconCreateRequest(d *schema.ResourceData, client *APIClient) (*sacloud.IconCreateRequest, error) {
 	body, err := expandIconBody(d)
 	if err != nil {
 		return nil, fmt.Errorf("creating SakuraCloud Icon is failed: %s", err)
 	}
 	return &sacloud.IconCreateRequest{
 		Name:  d.Get("name").(string),
-		Tags:  expandTags(d),
+		Tags:  expandTags(d, client),
 		Image: body,
 	}, nil
 }
 
-func expandIconUpdateRequest(d *schema.ResourceData) *sacloud.IconUpdateRequest {
+func expandIconUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.IconUpdateRequest {
 	return &sacloud.IconUpdateRequest{
 		Name: d.Get("name").(string),
-		Tags: expandTags(d),
+		Tags: expandTags(d, client),
 	}
 }
@@ -15,15 +15,22 @@
 package sakuracloud
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	internetBuilder "github.com/sacloud/libsacloud/v2/helper/builder/internet"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/search"
+	"github.com/sacloud/libsacloud/v2/sacloud/search/keys"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
 )
 
 func expandInternetBuilder(d *schema.ResourceData, client *APIClient) *internetBuilder.Builder {
 	return &internetBuilder.Builder{
 		Name:           d.Get("name").(string),
 		Description:    d.Get("description").(string),
-		Tags:           expandTags(d),
+		Tags:           expandTags(d, client),
 		IconID:         expandSakuraCloudID(d, "icon_id"),
 		NetworkMaskLen: d.Get("netmask").(int),
 		BandWidthMbps:  d.Get("band_width").(int),
@@ -31,3 +38,86 @@ func expandInternetBuilder(d *schema.ResourceData, client *APIClient) *internetB
 		Client:         internetBuilder.NewAPIClient(client),
 	}
 }
+
+// expandInternetRouterID スイッチ+ルータの現在のルータIDを返す
+//
+// 帯域変更後もリソースIDは変わらないため、API呼び出しにはrouter_idを利用する
+func expandInternetRouterID(d *schema.ResourceData) types.ID {
+	if routerID := d.Get("router_id").(string); routerID != "" {
+		return sakuraCloudID(routerID)
+	}
+	return sakuraCloudID(d.Id())
+}
+
+func flattenInternetRouterIDHistory(history []interface{}, previous, current types.ID) []interface{} {
+	if previous == current {
+		return history
+	}
+	return append(history, previous.String())
+}
+
+// internetIDTagFormat 帯域変更後のスイッチ+ルータのスイッチに付与する、sakuracloud_internetのリソースIDを記録するタグの書式
+//
+// 帯域変更でルータのIDが変わった後も、sakuracloud_subnetなどがリソースIDのままスイッチ+ルータを参照できるようにするために利用する
+const internetIDTagFormat = "tf-internet-id:%s"
+
+func internetIDTag(id types.ID) string {
+	return fmt.Sprintf(internetIDTagFormat, id)
+}
+
+// readInternet sakuracloud_internetのリソースIDから現在のスイッチ+ルータを返す
+//
+// 帯域変更でルータが削除されている場合は、スイッチに記録したリソースIDから現在のルータを探す
+func readInternet(ctx context.Context, client *APIClient, zone string, id types.ID) (*sacloud.Internet, error) {
+	internetOp := sacloud.NewInternetOp(client)
+	internet, err := internetOp.Read(ctx, zone, id)
+	if err == nil || !sacloud.IsNotFoundError(err) {
+		return internet, err
+	}
+
+	switches, findErr := sacloud.NewSwitchOp(client).Find(ctx, zone, &sacloud.FindCondition{
+		Filter: search.Filter{
+			search.Key(keys.Tags): search.TagsAndEqual(internetIDTag(id)),
+		},
+	})
+	if findErr != nil {
+		return nil, findErr
+	}
+	if len(switches.Switches) == 0 {
+		return nil, err
+	}
+
+	internets, findErr := internetOp.Find(ctx, zone, &sacloud.FindCondition{})
+	if findErr != nil {
+		return nil, findErr
+	}
+	for _, internet := range internets.Internet {
+		if internet.Switch != nil && internet.Switch.ID == switches.Switches[0].ID {
+			return internet, nil
+		}
+	}
+	return nil, err
+}
+
+// recordInternetID スイッチ+ルータのスイッチにsakuracloud_internetのリソースIDを記録する
+func recordInternetID(ctx context.Context, client *APIClient, zone string, id types.ID, internet *sacloud.Internet) error {
+	swOp := sacloud.NewSwitchOp(client)
+	sw, err := swOp.Read(ctx, zone, internet.Switch.ID)
+	if err != nil {
+		return err
+	}
+	tag := internetIDTag(id)
+	if sw.HasTag(tag) {
+		return nil
+	}
+
+	_, err = swOp.Update(ctx, zone, sw.ID, &sacloud.SwitchUpdateRequest{
+		Name:           sw.Name,
+		NetworkMaskLen: sw.NetworkMaskLen,
+		DefaultRoute:   sw.DefaultRoute,
+		Description:    sw.Description,
+		Tags:           append(sw.Tags, tag),
+		IconID:         sw.IconID,
+	})
+	return err
+}
@@ -0,0 +1,101 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/fake"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructureInternet_flattenInternetRouterIDHistory(t *testing.T) {
+	cases := []struct {
+		msg      string
+		history  []interface{}
+		previous types.ID
+		current  types.ID
+		expect   []interface{}
+	}{
+		{
+			msg:      "router id is not changed",
+			history:  []interface{}{"111111111111"},
+			previous: types.ID(222222222222),
+			current:  types.ID(222222222222),
+			expect:   []interface{}{"111111111111"},
+		},
+		{
+			msg:      "router id is changed",
+			history:  []interface{}{"111111111111"},
+			previous: types.ID(222222222222),
+			current:  types.ID(333333333333),
+			expect:   []interface{}{"111111111111", "222222222222"},
+		},
+		{
+			msg:      "first change",
+			previous: types.ID(111111111111),
+			current:  types.ID(222222222222),
+			expect:   []interface{}{"111111111111"},
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, flattenInternetRouterIDHistory(tc.history, tc.previous, tc.current), tc.msg)
+	}
+}
+
+func TestStructureInternet_readInternet(t *testing.T) {
+	setupFakeDefaultsForUnitTest(t)
+	fake.SwitchFactoryFuncToFake()
+
+	ctx := context.Background()
+	zone := "is1a"
+	client := &APIClient{}
+
+	internet, err := sacloud.NewInternetOp(client).Create(ctx, zone, &sacloud.InternetCreateRequest{
+		Name:           "internet",
+		NetworkMaskLen: 28,
+		BandWidthMbps:  100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 帯域変更前のルータIDはそのまま参照できる
+	found, err := readInternet(ctx, client, zone, internet.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, internet.ID, found.ID)
+
+	// 削除済みのルータIDはスイッチに記録されている場合のみ参照できる
+	removedID := types.ID(100000000001)
+	_, err = readInternet(ctx, client, zone, removedID)
+	assert.True(t, sacloud.IsNotFoundError(err))
+
+	assert.NoError(t, recordInternetID(ctx, client, zone, removedID, internet))
+	assert.NoError(t, recordInternetID(ctx, client, zone, removedID, internet))
+
+	found, err = readInternet(ctx, client, zone, removedID)
+	assert.NoError(t, err)
+	assert.Equal(t, internet.ID, found.ID)
+
+	sw, err := sacloud.NewSwitchOp(client).Read(ctx, zone, internet.Switch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, types.Tags{internetIDTag(removedID)}, sw.Tags)
+}
@@ -158,7 +158,7 @@ func flattenLoadBalancerNetworkInterface(lb *sacloud.LoadBalancer) []interface{}
 	}
 }
 
-func expandLoadBalancerCreateRequest(d *schema.ResourceData) *sacloud.LoadBalancerCreateRequest {
+func expandLoadBalancerCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.LoadBalancerCreateRequest {
 	nic := expandLoadBalancerNetworkInterface(d)
 	return &sacloud.LoadBalancerCreateRequest{
 		SwitchID:           nic.switchID,
@@ -169,16 +169,16 @@ func expandLoadBalancerCreateRequest(d *schema.ResourceData) *sacloud.LoadBalanc
 		DefaultRoute:       nic.gateway,
 		Name:               d.Get("name").(string),
 		Description:        d.Get("description").(string),
-		Tags:               expandTags(d),
+		Tags:               expandTags(d, client),
 		IconID:             expandSakuraCloudID(d, "icon_id"),
 		VirtualIPAddresses: expandLoadBalancerVIPs(d),
 	}
 }
-func expandLoadBalancerUpdateRequest(d *schema.ResourceData, lb *sacloud.LoadBalancer) *sacloud.LoadBalancerUpdateRequest {
+func expandLoadBalancerUpdateRequest(d *schema.ResourceData, client *APIClient, lb *sacloud.LoadBalancer) *sacloud.LoadBalancerUpdateRequest {
 	return &sacloud.LoadBalancerUpdateRequest{
 		Name:               d.Get("name").(string),
 		Description:        d.Get("description").(string),
-		Tags:               expandTags(d),
+		Tags:               expandTags(d, client),
 		IconID:             expandSakuraCloudID(d, "icon_id"),
 		VirtualIPAddresses: expandLoadBalancerVIPs(d),
 		SettingsHash:       lb.SettingsHash,
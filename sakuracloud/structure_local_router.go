@@ -24,7 +24,7 @@ func expandLocalRouterBuilder(d *schema.ResourceData, client *APIClient) *localr
 	return &localrouter.Builder{
 		Name:         stringOrDefault(d, "name"),
 		Description:  stringOrDefault(d, "description"),
-		Tags:         expandTags(d),
+		Tags:         expandTags(d, client),
 		IconID:       expandSakuraCloudID(d, "icon_id"),
 		Switch:       expandLocalRouterSwitch(d),
 		Interface:    expandLocalRouterNetworkInterface(d),
@@ -26,7 +26,7 @@ func expandMobileGatewayBuilder(d *schema.ResourceData, client *APIClient) *mobi
 	return &mobileGatewayBuilder.Builder{
 		Name:                            d.Get("name").(string),
 		Description:                     d.Get("description").(string),
-		Tags:                            expandTags(d),
+		Tags:                            expandTags(d, client),
 		IconID:                          expandSakuraCloudID(d, "icon_id"),
 		PrivateInterface:                expandMobileGatewayPrivateNetworks(d),
 		StaticRoutes:                    expandMobileGatewayStaticRoutes(d),
@@ -0,0 +1,243 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+)
+
+func schemaDataSourceMonitorTime(name string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+		Description:      descf("The %s time of the monitoring period in RFC3339 format", name),
+	}
+}
+
+func schemaDataSourceMonitorValues(description string, fields map[string]string) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time of the monitored value",
+		},
+	}
+	for name, desc := range fields {
+		s[name] = &schema.Schema{
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: desc,
+		}
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: s},
+		Description: description,
+	}
+}
+
+func schemaDataSourceMonitorLatestValue(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeFloat,
+		Computed:    true,
+		Description: description,
+	}
+}
+
+func expandMonitorCondition(d resourceValueGettable) *sacloud.MonitorCondition {
+	condition := &sacloud.MonitorCondition{}
+	if v, ok := d.GetOk("start"); ok {
+		condition.Start, _ = time.Parse(time.RFC3339, v.(string)) // validated by schema
+	}
+	if v, ok := d.GetOk("end"); ok {
+		condition.End, _ = time.Parse(time.RFC3339, v.(string)) // validated by schema
+	}
+	return condition
+}
+
+// monitorRate 使用率などの割合をパーセントで返す、分母が0の場合は0を返す
+func monitorRate(value, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return value / total * 100
+}
+
+func flattenMonitorTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func flattenMonitorCPUTimeValues(activity *sacloud.CPUTimeActivity) ([]interface{}, *sacloud.MonitorCPUTimeValue) {
+	var results []interface{}
+	latest := &sacloud.MonitorCPUTimeValue{}
+	for _, v := range activity.Values {
+		results = append(results, map[string]interface{}{
+			"time":     flattenMonitorTime(v.Time),
+			"cpu_time": v.CPUTime,
+		})
+		if v.Time.After(latest.Time) {
+			latest = v
+		}
+	}
+	return results, latest
+}
+
+func flattenMonitorDiskValues(activity *sacloud.DiskActivity) ([]interface{}, *sacloud.MonitorDiskValue) {
+	var results []interface{}
+	latest := &sacloud.MonitorDiskValue{}
+	for _, v := range activity.Values {
+		results = append(results, map[string]interface{}{
+			"time":  flattenMonitorTime(v.Time),
+			"read":  v.Read,
+			"write": v.Write,
+		})
+		if v.Time.After(latest.Time) {
+			latest = v
+		}
+	}
+	return results, latest
+}
+
+func flattenMonitorInterfaceValues(activity *sacloud.InterfaceActivity) ([]interface{}, *sacloud.MonitorInterfaceValue) {
+	var results []interface{}
+	latest := &sacloud.MonitorInterfaceValue{}
+	for _, v := range activity.Values {
+		results = append(results, map[string]interface{}{
+			"time":    flattenMonitorTime(v.Time),
+			"receive": v.Receive,
+			"send":    v.Send,
+		})
+		if v.Time.After(latest.Time) {
+			latest = v
+		}
+	}
+	return results, latest
+}
+
+func flattenMonitorDatabaseValues(activity *sacloud.DatabaseActivity) ([]interface{}, *sacloud.MonitorDatabaseValue) {
+	var results []interface{}
+	latest := &sacloud.MonitorDatabaseValue{}
+	for _, v := range activity.Values {
+		results = append(results, map[string]interface{}{
+			"time":                 flattenMonitorTime(v.Time),
+			"total_memory_size":    v.TotalMemorySize,
+			"used_memory_size":     v.UsedMemorySize,
+			"total_disk1_size":     v.TotalDisk1Size,
+			"used_disk1_size":      v.UsedDisk1Size,
+			"total_disk2_size":     v.TotalDisk2Size,
+			"used_disk2_size":      v.UsedDisk2Size,
+			"binlog_used_size_kib": v.BinlogUsedSizeKiB,
+			"delay_time_sec":       v.DelayTimeSec,
+		})
+		if v.Time.After(latest.Time) {
+			latest = v
+		}
+	}
+	return results, latest
+}
+
+func flattenMonitorFreeDiskSizeValues(activity *sacloud.FreeDiskSizeActivity) ([]interface{}, *sacloud.MonitorFreeDiskSizeValue) {
+	var results []interface{}
+	latest := &sacloud.MonitorFreeDiskSizeValue{}
+	for _, v := range activity.Values {
+		results = append(results, map[string]interface{}{
+			"time":           flattenMonitorTime(v.Time),
+			"free_disk_size": v.FreeDiskSize,
+		})
+		if v.Time.After(latest.Time) {
+			latest = v
+		}
+	}
+	return results, latest
+}
+
+func flattenMonitorRouterValues(activity *sacloud.RouterActivity) ([]interface{}, *sacloud.MonitorRouterValue) {
+	var results []interface{}
+	latest := &sacloud.MonitorRouterValue{}
+	for _, v := range activity.Values {
+		results = append(results, map[string]interface{}{
+			"time": flattenMonitorTime(v.Time),
+			"in":   v.In,
+			"out":  v.Out,
+		})
+		if v.Time.After(latest.Time) {
+			latest = v
+		}
+	}
+	return results, latest
+}
+
+func flattenMonitorLocalRouterValues(activity *sacloud.LocalRouterActivity) ([]interface{}, *sacloud.MonitorLocalRouterValue) {
+	var results []interface{}
+	latest := &sacloud.MonitorLocalRouterValue{}
+	for _, v := range activity.Values {
+		results = append(results, map[string]interface{}{
+			"time":                  flattenMonitorTime(v.Time),
+			"receive_bytes_per_sec": v.ReceiveBytesPerSec,
+			"send_bytes_per_sec":    v.SendBytesPerSec,
+		})
+		if v.Time.After(latest.Time) {
+			latest = v
+		}
+	}
+	return results, latest
+}
+
+// monitorPercentile valuesのパーセンタイル値をnearest-rank法で返す、valuesが空の場合は0を返す
+func monitorPercentile(values []float64, percentile float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// recommendInternetBandWidth トラフィック(bps)にheadroom(%)を加えた値を収容できる最小の帯域幅(Mbps)を返す
+//
+// 収容できる帯域幅が存在しない場合はbandWidthsの最大値を返す
+func recommendInternetBandWidth(bps float64, headroom int, bandWidths []int) int {
+	if len(bandWidths) == 0 {
+		return 0
+	}
+	sorted := make([]int, len(bandWidths))
+	copy(sorted, bandWidths)
+	sort.Ints(sorted)
+
+	required := bps * (1 + float64(headroom)/100) / 1000 / 1000
+	for _, bw := range sorted {
+		if float64(bw) >= required {
+			return bw
+		}
+	}
+	return sorted[len(sorted)-1]
+}
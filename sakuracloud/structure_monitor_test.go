@@ -0,0 +1,71 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructureMonitor_flattenMonitorInterfaceValues(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	activity := &sacloud.InterfaceActivity{
+		Values: []*sacloud.MonitorInterfaceValue{
+			{Time: now.Add(-5 * time.Minute), Receive: 1, Send: 2},
+			{Time: now, Receive: 3, Send: 4},
+			{Time: now.Add(-10 * time.Minute), Receive: 5, Send: 6},
+		},
+	}
+
+	values, latest := flattenMonitorInterfaceValues(activity)
+	assert.Len(t, values, 3)
+	assert.Equal(t, now, latest.Time)
+	assert.Equal(t, float64(3), latest.Receive)
+	assert.Equal(t, float64(4), latest.Send)
+
+	_, latest = flattenMonitorInterfaceValues(&sacloud.InterfaceActivity{})
+	assert.Equal(t, float64(0), latest.Receive)
+}
+
+func TestStructureMonitor_monitorRate(t *testing.T) {
+	assert.Equal(t, float64(25), monitorRate(1, 4))
+	assert.Equal(t, float64(0), monitorRate(1, 0))
+}
+
+func TestStructureMonitor_monitorPercentile(t *testing.T) {
+	values := []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5, 20, 11, 12, 13, 14, 15, 16, 17, 18, 19}
+
+	assert.Equal(t, float64(19), monitorPercentile(values, 95))
+	assert.Equal(t, float64(10), monitorPercentile(values, 50))
+	assert.Equal(t, float64(20), monitorPercentile(values, 100))
+	assert.Equal(t, float64(1), monitorPercentile(values, 1))
+	assert.Equal(t, float64(0), monitorPercentile(nil, 95))
+	// 元のスライスは変更しない
+	assert.Equal(t, float64(10), values[0])
+}
+
+func TestStructureMonitor_recommendInternetBandWidth(t *testing.T) {
+	bandWidths := []int{500, 100, 250, 1000}
+
+	assert.Equal(t, 100, recommendInternetBandWidth(0, 20, bandWidths))
+	assert.Equal(t, 100, recommendInternetBandWidth(80*1000*1000, 20, bandWidths))
+	assert.Equal(t, 250, recommendInternetBandWidth(90*1000*1000, 20, bandWidths))
+	assert.Equal(t, 100, recommendInternetBandWidth(90*1000*1000, 0, bandWidths))
+	assert.Equal(t, 1000, recommendInternetBandWidth(2000*1000*1000, 20, bandWidths))
+	assert.Equal(t, 0, recommendInternetBandWidth(1, 20, nil))
+}
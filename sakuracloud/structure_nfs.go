@@ -54,7 +54,7 @@ func flattenNFSDiskPlan(ctx context.Context, client *APIClient, planID types.ID)
 	return planName, size, nil
 }
 
-func expandNFSCreateRequest(d *schema.ResourceData, planID types.ID) *sacloud.NFSCreateRequest {
+func expandNFSCreateRequest(d *schema.ResourceData, client *APIClient, planID types.ID) *sacloud.NFSCreateRequest {
 	nic := expandNFSNetworkInterface(d)
 	return &sacloud.NFSCreateRequest{
 		SwitchID:       nic.switchID,
@@ -64,16 +64,16 @@ func expandNFSCreateRequest(d *schema.ResourceData, planID types.ID) *sacloud.NF
 		DefaultRoute:   nic.gateway,
 		Name:           d.Get("name").(string),
 		Description:    d.Get("description").(string),
-		Tags:           expandTags(d),
+		Tags:           expandTags(d, client),
 		IconID:         expandSakuraCloudID(d, "icon_id"),
 	}
 }
 
-func expandNFSUpdateRequest(d *schema.ResourceData) *sacloud.NFSUpdateRequest {
+func expandNFSUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.NFSUpdateRequest {
 	return &sacloud.NFSUpdateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 }
@@ -19,20 +19,20 @@ import (
 	"github.com/sacloud/libsacloud/v2/sacloud"
 )
 
-func expandNoteCreateRequest(d *schema.ResourceData) *sacloud.NoteCreateRequest {
+func expandNoteCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.NoteCreateRequest {
 	return &sacloud.NoteCreateRequest{
 		Name:    d.Get("name").(string),
-		Tags:    expandTags(d),
+		Tags:    expandTags(d, client),
 		IconID:  expandSakuraCloudID(d, "icon_id"),
 		Class:   d.Get("class").(string),
 		Content: d.Get("content").(string),
 	}
 }
 
-func expandNoteUpdateRequest(d *schema.ResourceData) *sacloud.NoteUpdateRequest {
+func expandNoteUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.NoteUpdateRequest {
 	return &sacloud.NoteUpdateRequest{
 		Name:    d.Get("name").(string),
-		Tags:    expandTags(d),
+		Tags:    expandTags(d, client),
 		IconID:  expandSakuraCloudID(d, "icon_id"),
 		Class:   d.Get("class").(string),
 		Content: d.Get("content").(string),
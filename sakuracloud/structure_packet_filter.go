@@ -15,6 +15,13 @@
 package sakuracloud
 
 import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/sacloud/libsacloud/v2/sacloud"
 	"github.com/sacloud/libsacloud/v2/sacloud/types"
@@ -114,3 +121,341 @@ func expandPacketFilterRulesDeleteRequest(_ *schema.ResourceData, pf *sacloud.Pa
 		Expression:  []*sacloud.PacketFilterExpression{},
 	}
 }
+
+// packetFilterEphemeralPorts 戻りパケットを受け付けるために許可が必要なエフェメラルポートの範囲
+var packetFilterEphemeralPorts = &packetFilterPortRange{from: 32768, to: 61000}
+
+// packetFilterAddressRange IPv4アドレスの範囲、nilの場合は全てのアドレスを表す
+type packetFilterAddressRange struct {
+	from, to uint32
+}
+
+func (r *packetFilterAddressRange) contains(o *packetFilterAddressRange) bool {
+	if r == nil {
+		return true
+	}
+	return o != nil && r.from <= o.from && o.to <= r.to
+}
+
+func (r *packetFilterAddressRange) equal(o *packetFilterAddressRange) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	return *r == *o
+}
+
+// packetFilterPortRange ポート番号の範囲、nilの場合は全てのポートを表す
+type packetFilterPortRange struct {
+	from, to int
+}
+
+func (r *packetFilterPortRange) contains(o *packetFilterPortRange) bool {
+	if r == nil {
+		return true
+	}
+	return o != nil && r.from <= o.from && o.to <= r.to
+}
+
+func (r *packetFilterPortRange) overlaps(o *packetFilterPortRange) bool {
+	if r == nil || o == nil {
+		return true
+	}
+	return r.from <= o.to && o.from <= r.to
+}
+
+func (r *packetFilterPortRange) equal(o *packetFilterPortRange) bool {
+	if r == nil || o == nil {
+		return r == o
+	}
+	return *r == *o
+}
+
+// packetFilterRule 解析用にパースしたパケットフィルタのルール
+type packetFilterRule struct {
+	protocol        types.Protocol
+	source          *packetFilterAddressRange
+	sourcePort      *packetFilterPortRange
+	destinationPort *packetFilterPortRange
+	allow           bool
+}
+
+func parsePacketFilterRule(exp *sacloud.PacketFilterExpression) (*packetFilterRule, error) {
+	rule := &packetFilterRule{
+		protocol: exp.Protocol,
+		allow:    exp.Action.IsAllow(),
+	}
+	source, err := parsePacketFilterNetwork(exp.SourceNetwork.String())
+	if err != nil {
+		return nil, err
+	}
+	rule.source = source
+
+	if rule.hasPorts() {
+		sourcePort, err := parsePacketFilterPort(exp.SourcePort.String())
+		if err != nil {
+			return nil, err
+		}
+		destinationPort, err := parsePacketFilterPort(exp.DestinationPort.String())
+		if err != nil {
+			return nil, err
+		}
+		rule.sourcePort = sourcePort
+		rule.destinationPort = destinationPort
+	}
+	return rule, nil
+}
+
+func (r *packetFilterRule) hasPorts() bool {
+	return r.protocol == types.Protocols.TCP || r.protocol == types.Protocols.UDP
+}
+
+// isCatchAll 全てのパケットにマッチするルールか
+func (r *packetFilterRule) isCatchAll() bool {
+	return r.protocol == types.Protocols.IP && r.source == nil
+}
+
+// deniesReturnTraffic 送信元を問わずエフェメラルポート宛の戻りパケットの一部でも拒否するルールか
+//
+// 送信元を限定した拒否ルールは意図的なものとして扱う
+func (r *packetFilterRule) deniesReturnTraffic(returnTraffic *packetFilterRule) bool {
+	if r.allow || r.source != nil {
+		return false
+	}
+	if r.protocol != types.Protocols.IP && r.protocol != returnTraffic.protocol {
+		return false
+	}
+	if !r.hasPorts() {
+		return true
+	}
+	return r.sourcePort == nil && r.destinationPort.overlaps(returnTraffic.destinationPort)
+}
+
+func (r *packetFilterRule) equal(o *packetFilterRule) bool {
+	return r.protocol == o.protocol &&
+		r.source.equal(o.source) &&
+		r.sourcePort.equal(o.sourcePort) &&
+		r.destinationPort.equal(o.destinationPort)
+}
+
+// covers oにマッチするパケットが全てrにもマッチするか
+func (r *packetFilterRule) covers(o *packetFilterRule) bool {
+	if r.protocol != types.Protocols.IP && r.protocol != o.protocol {
+		return false
+	}
+	if !r.source.contains(o.source) {
+		return false
+	}
+	if !r.hasPorts() {
+		return true
+	}
+	return r.sourcePort.contains(o.sourcePort) && r.destinationPort.contains(o.destinationPort)
+}
+
+// packetFilterPacket ルールの評価に用いるパケット
+type packetFilterPacket struct {
+	protocol        types.Protocol
+	source          uint32
+	sourcePort      int
+	destinationPort int
+}
+
+func (r *packetFilterRule) match(p *packetFilterPacket) bool {
+	if r.protocol != types.Protocols.IP && r.protocol != p.protocol {
+		return false
+	}
+	if !r.source.contains(&packetFilterAddressRange{from: p.source, to: p.source}) {
+		return false
+	}
+	if !r.hasPorts() {
+		return true
+	}
+	return r.sourcePort.contains(&packetFilterPortRange{from: p.sourcePort, to: p.sourcePort}) &&
+		r.destinationPort.contains(&packetFilterPortRange{from: p.destinationPort, to: p.destinationPort})
+}
+
+// parsePacketFilterNetwork IPアドレス、CIDRブロック、またはアドレス/ネットマスク形式の文字列をパースする
+func parsePacketFilterNetwork(network string) (*packetFilterAddressRange, error) {
+	if network == "" {
+		return nil, nil
+	}
+
+	addr, mask := network, ""
+	if i := strings.Index(network, "/"); i >= 0 {
+		addr, mask = network[:i], network[i+1:]
+	}
+	ip, err := parsePacketFilterIPv4(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source_network %q: %s", network, err)
+	}
+	if mask == "" {
+		return &packetFilterAddressRange{from: ip, to: ip}, nil
+	}
+
+	var bits int
+	if n, err := strconv.Atoi(mask); err == nil {
+		bits = n
+	} else {
+		m := net.ParseIP(mask).To4()
+		if m == nil {
+			return nil, fmt.Errorf("invalid source_network %q: invalid mask %q", network, mask)
+		}
+		ones, size := net.IPMask(m).Size()
+		if size == 0 {
+			return nil, fmt.Errorf("invalid source_network %q: invalid mask %q", network, mask)
+		}
+		bits = ones
+	}
+	if bits < 0 || bits > 32 {
+		return nil, fmt.Errorf("invalid source_network %q: invalid mask %q", network, mask)
+	}
+
+	if bits == 0 {
+		return nil, nil // 0.0.0.0/0は全てのアドレスとして扱う
+	}
+	hostBits := uint32(1<<uint(32-bits)) - 1
+	return &packetFilterAddressRange{from: ip &^ hostBits, to: ip | hostBits}, nil
+}
+
+func parsePacketFilterIPv4(addr string) (uint32, error) {
+	ip := net.ParseIP(addr).To4()
+	if ip == nil {
+		return 0, fmt.Errorf("%q is not an IPv4 address", addr)
+	}
+	return binary.BigEndian.Uint32(ip), nil
+}
+
+// parsePacketFilterPort ポート番号、またはポート範囲(from-to)形式の文字列をパースする
+func parsePacketFilterPort(port string) (*packetFilterPortRange, error) {
+	if port == "" {
+		return nil, nil
+	}
+
+	from, to := port, port
+	if i := strings.Index(port, "-"); i >= 0 {
+		from, to = port[:i], port[i+1:]
+	}
+	f, err := strconv.Atoi(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", port)
+	}
+	t, err := strconv.Atoi(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q", port)
+	}
+	if f < 0 || t > 65535 || f > t {
+		return nil, fmt.Errorf("invalid port %q", port)
+	}
+	if f == 0 && t == 65535 {
+		return nil, nil // 0-65535は全てのポートとして扱う
+	}
+	return &packetFilterPortRange{from: f, to: t}, nil
+}
+
+// analyzePacketFilterExpressions ルールの並びを解析し、警告メッセージのリストを返す
+//
+// パケットフィルタは先頭から順にルールを評価し、どのルールにもマッチしなかったパケットは許可される
+func analyzePacketFilterExpressions(expressions []*sacloud.PacketFilterExpression) []string {
+	if len(expressions) == 0 {
+		return nil
+	}
+
+	var warnings []string
+	var catchAll *packetFilterRule
+	catchAllIndex := -1
+	var rules []*packetFilterRule
+	var indexes []int
+
+	for i, exp := range expressions {
+		rule, err := parsePacketFilterRule(exp)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("expression[%d] could not be analyzed: %s", i, err))
+			continue
+		}
+
+		if catchAll != nil {
+			warnings = append(warnings, fmt.Sprintf("expression[%d] is unreachable: expression[%d] matches all packets", i, catchAllIndex))
+			continue
+		}
+		for j, prev := range rules {
+			if prev.equal(rule) {
+				warnings = append(warnings, fmt.Sprintf("expression[%d] is a duplicate of expression[%d]", i, indexes[j]))
+				break
+			}
+			if prev.covers(rule) {
+				warnings = append(warnings, fmt.Sprintf("expression[%d] is shadowed by expression[%d]", i, indexes[j]))
+				break
+			}
+		}
+
+		if rule.isCatchAll() {
+			catchAll = rule
+			catchAllIndex = i
+		}
+		rules = append(rules, rule)
+		indexes = append(indexes, i)
+	}
+
+	if catchAll == nil || catchAll.allow {
+		warnings = append(warnings, "expressions don't end with the rule to deny all packets(protocol: ip, allow: false): packets that don't match any expression are allowed")
+		return warnings
+	}
+
+	// 外向きの通信の戻りパケットはエフェメラルポート宛となるため、TCP/UDPそれぞれで許可されているか確認する
+	for _, protocol := range []types.Protocol{types.Protocols.TCP, types.Protocols.UDP} {
+		returnTraffic := &packetFilterRule{
+			protocol:        protocol,
+			destinationPort: packetFilterEphemeralPorts,
+		}
+		// 先頭から順に評価し、戻りパケットを全て許可するルールより先に拒否するルールがないか確認する
+		for j, rule := range rules {
+			if rule.allow && rule.covers(returnTraffic) {
+				break
+			}
+			if !rule.deniesReturnTraffic(returnTraffic) {
+				continue
+			}
+			if indexes[j] == catchAllIndex {
+				warnings = append(warnings, fmt.Sprintf(
+					"no expression allows return traffic of %s: add the rule to allow destination_port %d-%d before expression[%d]",
+					protocol, packetFilterEphemeralPorts.from, packetFilterEphemeralPorts.to, catchAllIndex,
+				))
+			} else {
+				warnings = append(warnings, fmt.Sprintf(
+					"return traffic of %s is denied by expression[%d]: add the rule to allow destination_port %d-%d before expression[%d]",
+					protocol, indexes[j], packetFilterEphemeralPorts.from, packetFilterEphemeralPorts.to, indexes[j],
+				))
+			}
+			break
+		}
+	}
+	return warnings
+}
+
+// evaluatePacketFilterExpressions パケットが最初にマッチするルールのインデックスを返す、マッチしない場合は-1を返す
+func evaluatePacketFilterExpressions(expressions []*sacloud.PacketFilterExpression, packet *packetFilterPacket) (int, error) {
+	for i, exp := range expressions {
+		rule, err := parsePacketFilterRule(exp)
+		if err != nil {
+			return -1, fmt.Errorf("expression[%d] could not be evaluated: %s", i, err)
+		}
+		if rule.match(packet) {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+func customizeDiffPacketFilterExpressions(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" && !d.HasChange("expression") {
+		return nil
+	}
+	if !d.NewValueKnown("expression") {
+		return d.SetNewComputed("expression_warnings")
+	}
+
+	return d.SetNew("expression_warnings", analyzePacketFilterExpressions(expandPacketFilterExpressions(d)))
+}
+
+func flattenPacketFilterExpressionWarnings(pf *sacloud.PacketFilter) []string {
+	return analyzePacketFilterExpressions(pf.Expression)
+}
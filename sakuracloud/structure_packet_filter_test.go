@@ -0,0 +1,230 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPacketFilterExpression(protocol, network, srcPort, dstPort string, allow bool) *sacloud.PacketFilterExpression {
+	action := types.Actions.Deny
+	if allow {
+		action = types.Actions.Allow
+	}
+	return &sacloud.PacketFilterExpression{
+		Protocol:        types.Protocol(protocol),
+		SourceNetwork:   types.PacketFilterNetwork(network),
+		SourcePort:      types.PacketFilterPort(srcPort),
+		DestinationPort: types.PacketFilterPort(dstPort),
+		Action:          action,
+	}
+}
+
+func TestStructurePacketFilter_parsePacketFilterNetwork(t *testing.T) {
+	cases := []struct {
+		in     string
+		expect *packetFilterAddressRange
+		err    bool
+	}{
+		{in: "", expect: nil},
+		{in: "0.0.0.0/0", expect: nil},
+		{in: "192.0.2.1", expect: &packetFilterAddressRange{from: 0xc0000201, to: 0xc0000201}},
+		{in: "192.0.2.0/24", expect: &packetFilterAddressRange{from: 0xc0000200, to: 0xc00002ff}},
+		{in: "192.0.2.1/255.255.255.0", expect: &packetFilterAddressRange{from: 0xc0000200, to: 0xc00002ff}},
+		{in: "192.0.2.1/33", err: true},
+		{in: "example.com", err: true},
+	}
+	for _, tc := range cases {
+		got, err := parsePacketFilterNetwork(tc.in)
+		assert.Equal(t, tc.err, err != nil, tc.in)
+		assert.Equal(t, tc.expect, got, tc.in)
+	}
+}
+
+func TestStructurePacketFilter_parsePacketFilterPort(t *testing.T) {
+	cases := []struct {
+		in     string
+		expect *packetFilterPortRange
+		err    bool
+	}{
+		{in: "", expect: nil},
+		{in: "0-65535", expect: nil},
+		{in: "80", expect: &packetFilterPortRange{from: 80, to: 80}},
+		{in: "1024-2048", expect: &packetFilterPortRange{from: 1024, to: 2048}},
+		{in: "2048-1024", err: true},
+		{in: "http", err: true},
+	}
+	for _, tc := range cases {
+		got, err := parsePacketFilterPort(tc.in)
+		assert.Equal(t, tc.err, err != nil, tc.in)
+		assert.Equal(t, tc.expect, got, tc.in)
+	}
+}
+
+func TestStructurePacketFilter_analyzePacketFilterExpressions(t *testing.T) {
+	cases := []struct {
+		msg         string
+		expressions []*sacloud.PacketFilterExpression
+		expect      []string
+	}{
+		{
+			msg:    "empty",
+			expect: nil,
+		},
+		{
+			msg: "valid",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "22", true),
+				testPacketFilterExpression("tcp", "", "", "32768-61000", true),
+				testPacketFilterExpression("udp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "", "", "", false),
+			},
+			expect: nil,
+		},
+		{
+			msg: "duplicate and shadowed",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "192.0.2.0/24", "", "", true),
+				testPacketFilterExpression("tcp", "192.0.2.0/24", "", "", false),
+				testPacketFilterExpression("tcp", "192.0.2.1", "", "80", false),
+				testPacketFilterExpression("tcp", "", "", "32768-61000", true),
+				testPacketFilterExpression("udp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "", "", "", false),
+			},
+			expect: []string{
+				"expression[1] is a duplicate of expression[0]",
+				"expression[2] is shadowed by expression[0]",
+			},
+		},
+		{
+			msg: "unreachable",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "32768-61000", true),
+				testPacketFilterExpression("udp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "0.0.0.0/0", "", "", false),
+				testPacketFilterExpression("tcp", "", "", "22", true),
+			},
+			expect: []string{
+				"expression[3] is unreachable: expression[2] matches all packets",
+			},
+		},
+		{
+			msg: "missing deny all",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "22", true),
+			},
+			expect: []string{
+				"expressions don't end with the rule to deny all packets(protocol: ip, allow: false): packets that don't match any expression are allowed",
+			},
+		},
+		{
+			msg: "missing return traffic",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "22", true),
+				testPacketFilterExpression("tcp", "", "", "32768-40000", true),
+				testPacketFilterExpression("udp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "", "", "", false),
+			},
+			expect: []string{
+				"no expression allows return traffic of tcp: add the rule to allow destination_port 32768-61000 before expression[3]",
+			},
+		},
+		{
+			msg: "missing return traffic of udp",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "32768-61000", true),
+				testPacketFilterExpression("udp", "192.0.2.1", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "", "", "", false),
+			},
+			expect: []string{
+				"no expression allows return traffic of udp: add the rule to allow destination_port 32768-61000 before expression[2]",
+			},
+		},
+		{
+			msg: "return traffic denied before allowed",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "32768-40000", false),
+				testPacketFilterExpression("tcp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "192.0.2.1", "", "", false),
+				testPacketFilterExpression("udp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "", "", "", false),
+			},
+			expect: []string{
+				"return traffic of tcp is denied by expression[0]: add the rule to allow destination_port 32768-61000 before expression[0]",
+			},
+		},
+		{
+			msg: "return traffic denied by all protocols rule",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "", "", "", false),
+				testPacketFilterExpression("udp", "", "", "32768-61000", true),
+			},
+			expect: []string{
+				"expression[2] is unreachable: expression[1] matches all packets",
+				"no expression allows return traffic of udp: add the rule to allow destination_port 32768-61000 before expression[1]",
+			},
+		},
+		{
+			msg: "invalid",
+			expressions: []*sacloud.PacketFilterExpression{
+				testPacketFilterExpression("tcp", "", "", "ssh", true),
+				testPacketFilterExpression("tcp", "", "", "32768-61000", true),
+				testPacketFilterExpression("udp", "", "", "32768-61000", true),
+				testPacketFilterExpression("ip", "", "", "", false),
+			},
+			expect: []string{
+				`expression[0] could not be analyzed: invalid port "ssh"`,
+			},
+		},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, analyzePacketFilterExpressions(tc.expressions), tc.msg)
+	}
+}
+
+func TestStructurePacketFilter_evaluatePacketFilterExpressions(t *testing.T) {
+	expressions := []*sacloud.PacketFilterExpression{
+		testPacketFilterExpression("tcp", "192.0.2.0/24", "", "22", true),
+		testPacketFilterExpression("udp", "", "123", "123", true),
+		testPacketFilterExpression("icmp", "", "", "", true),
+		testPacketFilterExpression("ip", "", "", "", false),
+	}
+	source, _ := parsePacketFilterIPv4("192.0.2.10")
+	other, _ := parsePacketFilterIPv4("198.51.100.1")
+
+	cases := []struct {
+		packet *packetFilterPacket
+		expect int
+	}{
+		{packet: &packetFilterPacket{protocol: "tcp", source: source, sourcePort: 40000, destinationPort: 22}, expect: 0},
+		{packet: &packetFilterPacket{protocol: "tcp", source: other, sourcePort: 40000, destinationPort: 22}, expect: 3},
+		{packet: &packetFilterPacket{protocol: "udp", source: other, sourcePort: 123, destinationPort: 123}, expect: 1},
+		{packet: &packetFilterPacket{protocol: "icmp", source: other}, expect: 2},
+	}
+	for _, tc := range cases {
+		got, err := evaluatePacketFilterExpressions(expressions, tc.packet)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expect, got)
+	}
+
+	got, err := evaluatePacketFilterExpressions(expressions[:1], &packetFilterPacket{protocol: "udp", source: source})
+	assert.NoError(t, err)
+	assert.Equal(t, -1, got)
+}
@@ -0,0 +1,191 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sacloud/libsacloud/v2/helper/query"
+	"github.com/sacloud/libsacloud/v2/pkg/size"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/naked"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+)
+
+func schemaDataSourcePlanAvailableOnly() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "The flag to return only available plans",
+	}
+}
+
+func schemaDataSourcePlanIDs(resourceName string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: descf("A list of the %s plan id", resourceName),
+	}
+}
+
+func schemaDataSourcePlans(resourceName string, planSchema map[string]*schema.Schema) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: descf("The id of the %s plan", resourceName),
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: descf("The name of the %s plan", resourceName),
+		},
+		"availability": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: descf("The availability of the %s plan", resourceName),
+		},
+	}
+	for k, v := range planSchema {
+		s[k] = v
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: s},
+		Description: descf("A list of the %s plan", resourceName),
+	}
+}
+
+// isPlanAvailabilityMatched available_onlyが指定されている場合、有効なプランのみtrueを返す
+func isPlanAvailabilityMatched(d resourceValueGettable, availability types.EAvailability) bool {
+	return !boolOrDefault(d, "available_only") || availability.IsAvailable()
+}
+
+// isPlanIntValueMatched keyが指定されている場合のみvalueと一致するか判定する
+func isPlanIntValueMatched(d resourceValueGettable, key string, value int) bool {
+	v, ok := d.GetOk(key)
+	return !ok || v.(int) == value
+}
+
+// isPlanStringValueMatched keyが指定されている場合のみvalueと一致するか判定する
+func isPlanStringValueMatched(d resourceValueGettable, key string, value string) bool {
+	v, ok := d.GetOk(key)
+	return !ok || v.(string) == value
+}
+
+func setPlanDataSourceResourceData(d *schema.ResourceData, client *APIClient, prefix string, ids []string, plans []interface{}) diag.Diagnostics {
+	d.SetId(fmt.Sprintf("%s-%s-%d", prefix, getZone(d, client), schema.HashString(strings.Join(ids, ","))))
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("plans", plans); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("zone", getZone(d, client)) // nolint
+	return nil
+}
+
+// readNakedPlan プランAPIのレスポンスをdestへ読み込む
+//
+// libsacloudのプランのモデルにはサービスクラスが含まれないため、各プランのOpのパス設定を元にAPIを直接呼び出す
+func readNakedPlan(ctx context.Context, planOp interface{}, zone string, id types.ID, dest interface{}) error {
+	var caller sacloud.APICaller
+	var pathSuffix, pathName string
+	switch op := planOp.(type) {
+	case *sacloud.ServerPlanOp:
+		caller, pathSuffix, pathName = op.Client, op.PathSuffix, op.PathName
+	case *sacloud.DiskPlanOp:
+		caller, pathSuffix, pathName = op.Client, op.PathSuffix, op.PathName
+	case *sacloud.InternetPlanOp:
+		caller, pathSuffix, pathName = op.Client, op.PathSuffix, op.PathName
+	default:
+		// fakeドライバではプランのサービスクラスは参照できない
+		return errors.New("reading service class of plans is not supported by the current API driver")
+	}
+
+	url := strings.Join([]string{sacloud.SakuraCloudAPIRoot, zone, pathSuffix, pathName, id.String()}, "/")
+	data, err := caller.Do(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// findServerPlanServiceClass core/memory/commitmentに該当するサーバプランのサービスクラスのパスを返す
+func findServerPlanServiceClass(ctx context.Context, planOp sacloud.ServerPlanAPI, zone string, core, memory int, commitment types.ECommitment) (string, error) {
+	plan, err := query.FindServerPlan(ctx, planOp, zone, &query.FindServerPlanRequest{
+		CPU:        core,
+		MemoryGB:   memory,
+		Commitment: commitment,
+		Generation: types.PlanGenerations.Default,
+	})
+	if err != nil {
+		return "", fmt.Errorf("server plan[core: %d, memory: %dGB, commitment: %s] is not found: %s", core, memory, commitment, err)
+	}
+
+	var res struct{ ServerPlan *naked.ServerPlan }
+	if err := readNakedPlan(ctx, planOp, zone, plan.ID, &res); err != nil {
+		return "", err
+	}
+	if res.ServerPlan == nil || res.ServerPlan.ServiceClass == "" {
+		return "", fmt.Errorf("service class of server plan[%s] is not found", plan.ID)
+	}
+	return res.ServerPlan.ServiceClass, nil
+}
+
+// findDiskPlanServiceClass プラン名/サイズ(GiB)に該当するディスクプランのサービスクラスのパスを返す
+func findDiskPlanServiceClass(ctx context.Context, planOp sacloud.DiskPlanAPI, zone string, plan string, sizeGB int) (string, error) {
+	var res struct{ DiskPlan *naked.DiskPlan }
+	if err := readNakedPlan(ctx, planOp, zone, types.DiskPlanIDMap[plan], &res); err != nil {
+		return "", err
+	}
+	if res.DiskPlan != nil {
+		for _, s := range res.DiskPlan.Size {
+			if s.SizeMB == size.GiBToMiB(sizeGB) && s.ServiceClass != "" {
+				return s.ServiceClass, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("disk plan[plan: %s, size: %dGB] is not found", plan, sizeGB)
+}
+
+// findInternetPlanServiceClass 帯域幅(Mbps)に該当するルータプランのサービスクラスのパスを返す
+func findInternetPlanServiceClass(ctx context.Context, planOp sacloud.InternetPlanAPI, zone string, bandWidth int) (string, error) {
+	searched, err := planOp.Find(ctx, zone, &sacloud.FindCondition{})
+	if err != nil {
+		return "", err
+	}
+	for _, plan := range searched.InternetPlans {
+		if plan.BandWidthMbps != bandWidth {
+			continue
+		}
+		var res struct{ InternetPlan *naked.InternetPlan }
+		if err := readNakedPlan(ctx, planOp, zone, plan.ID, &res); err != nil {
+			return "", err
+		}
+		if res.InternetPlan != nil && res.InternetPlan.ServiceClass != "" {
+			return res.InternetPlan.ServiceClass, nil
+		}
+	}
+	return "", fmt.Errorf("internet plan[band_width: %dMbps] is not found", bandWidth)
+}
@@ -0,0 +1,176 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsServerPlanMatched(t *testing.T) {
+	plan := &sacloud.ServerPlan{
+		ID:           1,
+		CPU:          2,
+		MemoryMB:     4 * 1024,
+		Commitment:   types.Commitments.Standard,
+		Generation:   types.PlanGenerations.G200,
+		Availability: types.Availabilities.Available,
+	}
+
+	cases := []struct {
+		msg    string
+		in     map[string]interface{}
+		expect bool
+	}{
+		{
+			msg:    "without conditions",
+			in:     map[string]interface{}{},
+			expect: true,
+		},
+		{
+			msg: "matched",
+			in: map[string]interface{}{
+				"core":           2,
+				"memory":         4,
+				"commitment":     "standard",
+				"generation":     200,
+				"available_only": true,
+			},
+			expect: true,
+		},
+		{
+			msg:    "core is not matched",
+			in:     map[string]interface{}{"core": 4},
+			expect: false,
+		},
+		{
+			msg:    "memory is not matched",
+			in:     map[string]interface{}{"memory": 2},
+			expect: false,
+		},
+		{
+			msg:    "commitment is not matched",
+			in:     map[string]interface{}{"commitment": "dedicatedcpu"},
+			expect: false,
+		},
+		{
+			msg:    "generation is not matched",
+			in:     map[string]interface{}{"generation": 100},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, isServerPlanMatched(mapToResourceData(tc.in), plan), tc.msg)
+	}
+}
+
+func TestIsDiskPlanMatched(t *testing.T) {
+	plan := &sacloud.DiskPlan{
+		ID:           types.DiskPlans.SSD,
+		Availability: types.Availabilities.Available,
+		Size: []*sacloud.DiskPlanSizeInfo{
+			{Availability: types.Availabilities.Available, SizeMB: 20 * 1024},
+			{Availability: types.Availabilities.Discontinued, SizeMB: 40 * 1024},
+		},
+	}
+
+	cases := []struct {
+		msg    string
+		in     map[string]interface{}
+		expect bool
+	}{
+		{
+			msg:    "without conditions",
+			in:     map[string]interface{}{},
+			expect: true,
+		},
+		{
+			msg:    "plan is not matched",
+			in:     map[string]interface{}{"plan": "hdd"},
+			expect: false,
+		},
+		{
+			msg:    "size is matched",
+			in:     map[string]interface{}{"plan": "ssd", "size": 40},
+			expect: true,
+		},
+		{
+			msg:    "size is not available",
+			in:     map[string]interface{}{"size": 40, "available_only": true},
+			expect: false,
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, isDiskPlanMatched(mapToResourceData(tc.in), plan), tc.msg)
+	}
+	assert.Equal(t, []int{20}, flattenDiskPlanSizes(mapToResourceData(map[string]interface{}{"available_only": true}), plan))
+}
+
+// testPlanAPICaller URLのパスの末尾に応じて固定のレスポンスを返すAPICaller
+type testPlanAPICaller struct {
+	responses map[string]string
+}
+
+func (c *testPlanAPICaller) Do(_ context.Context, _, uri string, _ interface{}) ([]byte, error) {
+	path := strings.SplitN(uri, "?", 2)[0]
+	for suffix, res := range c.responses {
+		if strings.HasSuffix(path, suffix) {
+			return []byte(res), nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected request: %s", uri)
+}
+
+func TestFindPlanServiceClass(t *testing.T) {
+	ctx := context.Background()
+	caller := &testPlanAPICaller{
+		responses: map[string]string{
+			"/product/server":           `{"Count":1,"Total":1,"ServerPlans":[{"ID":100002004,"CPU":2,"MemoryMB":4096,"Commitment":"standard","Generation":200,"Availability":"available"}]}`,
+			"/product/server/100002004": `{"ServerPlan":{"ID":100002004,"CPU":2,"MemoryMB":4096,"ServiceClass":"cloud/plan/core/2core-4gb","Availability":"available"}}`,
+			"/product/disk/4":           `{"DiskPlan":{"ID":4,"Size":[{"SizeMB":20480,"ServiceClass":"cloud/disk/ssd/20g"},{"SizeMB":40960,"ServiceClass":"cloud/disk/ssd/40g"}]}}`,
+			"/product/internet":         `{"Count":1,"Total":1,"InternetPlans":[{"ID":100,"BandWidthMbps":100,"Availability":"available"}]}`,
+			"/product/internet/100":     `{"InternetPlan":{"ID":100,"BandWidthMbps":100,"ServiceClass":"cloud/internet/router/100m"}}`,
+		},
+	}
+
+	serverPlanOp := &sacloud.ServerPlanOp{Client: caller, PathSuffix: "api/cloud/1.1", PathName: "product/server"}
+	serviceClass, err := findServerPlanServiceClass(ctx, serverPlanOp, "is1a", 2, 4, types.Commitments.Standard)
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud/plan/core/2core-4gb", serviceClass)
+
+	diskPlanOp := &sacloud.DiskPlanOp{Client: caller, PathSuffix: "api/cloud/1.1", PathName: "product/disk"}
+	serviceClass, err = findDiskPlanServiceClass(ctx, diskPlanOp, "is1a", "ssd", 40)
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud/disk/ssd/40g", serviceClass)
+
+	_, err = findDiskPlanServiceClass(ctx, diskPlanOp, "is1a", "ssd", 30)
+	assert.Error(t, err)
+
+	internetPlanOp := &sacloud.InternetPlanOp{Client: caller, PathSuffix: "api/cloud/1.1", PathName: "product/internet"}
+	serviceClass, err = findInternetPlanServiceClass(ctx, internetPlanOp, "is1a", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud/internet/router/100m", serviceClass)
+
+	_, err = findInternetPlanServiceClass(ctx, internetPlanOp, "is1a", 250)
+	assert.Error(t, err)
+}
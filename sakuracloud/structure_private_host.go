@@ -40,21 +40,21 @@ func expandPrivateHostPlanID(ctx context.Context, d resourceValueGettable, clien
 	return searched.PrivateHostPlans[0].ID, nil
 }
 
-func expandPrivateHostCreateRequest(d *schema.ResourceData, planID types.ID) *sacloud.PrivateHostCreateRequest {
+func expandPrivateHostCreateRequest(d *schema.ResourceData, client *APIClient, planID types.ID) *sacloud.PrivateHostCreateRequest {
 	return &sacloud.PrivateHostCreateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 		PlanID:      planID,
 	}
 }
 
-func expandPrivateHostUpdateRequest(d *schema.ResourceData) *sacloud.PrivateHostUpdateRequest {
+func expandPrivateHostUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.PrivateHostUpdateRequest {
 	return &sacloud.PrivateHostUpdateRequest{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 	}
 }
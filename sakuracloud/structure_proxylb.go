@@ -20,7 +20,7 @@ import (
 	"github.com/sacloud/libsacloud/v2/sacloud/types"
 )
 
-func expandProxyLBCreateRequest(d *schema.ResourceData) *sacloud.ProxyLBCreateRequest {
+func expandProxyLBCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.ProxyLBCreateRequest {
 	return &sacloud.ProxyLBCreateRequest{
 		Plan:           types.EProxyLBPlan(d.Get("plan").(int)),
 		HealthCheck:    expandProxyLBHealthCheck(d),
@@ -35,12 +35,12 @@ func expandProxyLBCreateRequest(d *schema.ResourceData) *sacloud.ProxyLBCreateRe
 		Region:         types.EProxyLBRegion(d.Get("region").(string)),
 		Name:           d.Get("name").(string),
 		Description:    d.Get("description").(string),
-		Tags:           expandTags(d),
+		Tags:           expandTags(d, client),
 		IconID:         expandSakuraCloudID(d, "icon_id"),
 	}
 }
 
-func expandProxyLBUpdateRequest(d *schema.ResourceData) *sacloud.ProxyLBUpdateRequest {
+func expandProxyLBUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.ProxyLBUpdateRequest {
 	return &sacloud.ProxyLBUpdateRequest{
 		HealthCheck:   expandProxyLBHealthCheck(d),
 		SorryServer:   expandProxyLBSorryServer(d),
@@ -52,7 +52,7 @@ func expandProxyLBUpdateRequest(d *schema.ResourceData) *sacloud.ProxyLBUpdateRe
 		Timeout:       expandProxyLBTimeout(d),
 		Name:          d.Get("name").(string),
 		Description:   d.Get("description").(string),
-		Tags:          expandTags(d),
+		Tags:          expandTags(d, client),
 		IconID:        expandSakuraCloudID(d, "icon_id"),
 	}
 }
@@ -16,6 +16,7 @@ package sakuracloud
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 
@@ -41,7 +42,7 @@ func expandServerBuilder(ctx context.Context, zone string, d *schema.ResourceDat
 		InterfaceDriver: types.EInterfaceDriver(d.Get("interface_driver").(string)),
 		Description:     d.Get("description").(string),
 		IconID:          expandSakuraCloudID(d, "icon_id"),
-		Tags:            expandTags(d),
+		Tags:            expandTags(d, client),
 		CDROMID:         expandSakuraCloudID(d, "cdrom_id"),
 		PrivateHostID:   expandSakuraCloudID(d, "private_host_id"),
 		NIC:             expandServerNIC(d),
@@ -76,19 +77,7 @@ func expandServerDisks(ctx context.Context, zone string, d *schema.ResourceData,
 			if diskEdit, ok := d.GetOk("disk_edit_parameter"); ok {
 				v := mapToResourceData(diskEdit.([]interface{})[0].(map[string]interface{}))
 				log.Printf("[INFO] disk_edit_parameter is specified for Disk[%s]", diskID)
-				b.EditParameter = &diskBuilder.UnixEditRequest{
-					HostName:            stringOrDefault(v, "hostname"),
-					Password:            stringOrDefault(v, "password"),
-					DisablePWAuth:       boolOrDefault(v, "disable_pw_auth"),
-					EnableDHCP:          boolOrDefault(v, "enable_dhcp"),
-					ChangePartitionUUID: boolOrDefault(v, "change_partition_uuid"),
-					IPAddress:           stringOrDefault(v, "ip_address"),
-					NetworkMaskLen:      intOrDefault(v, "netmask"),
-					DefaultRoute:        stringOrDefault(v, "gateway"),
-					SSHKeys:             stringListOrDefault(v, "ssh_keys"),
-					SSHKeyIDs:           expandSakuraCloudIDs(v, "ssh_key_ids"),
-					Notes:               expandDiskEditNotes(v),
-				}
+				b.EditParameter = expandDiskEditParameter(v)
 			}
 		}
 		builders = append(builders, b)
@@ -96,27 +85,6 @@ func expandServerDisks(ctx context.Context, zone string, d *schema.ResourceData,
 	return builders, nil
 }
 
-func expandDiskEditNotes(d resourceValueGettable) []*sacloud.DiskEditNote {
-	var notes []*sacloud.DiskEditNote
-	if _, ok := d.GetOk("note_ids"); ok {
-		ids := expandSakuraCloudIDs(d, "note_ids")
-		for _, id := range ids {
-			notes = append(notes, &sacloud.DiskEditNote{ID: id})
-		}
-	}
-	if values, ok := d.GetOk("note"); ok { // nolint
-		for _, value := range values.([]interface{}) {
-			d = mapToResourceData(value.(map[string]interface{}))
-			notes = append(notes, &sacloud.DiskEditNote{
-				ID:        expandSakuraCloudID(d, "id"),
-				APIKeyID:  expandSakuraCloudID(d, "api_key_id"),
-				Variables: d.Get("variables").(map[string]interface{}),
-			})
-		}
-	}
-	return notes
-}
-
 func expandServerNIC(d resourceValueGettable) serverBuilder.NICSettingHolder {
 	nics := d.Get("network_interface").([]interface{})
 	if len(nics) == 0 {
@@ -169,6 +137,67 @@ func expandServerAdditionalNICs(d resourceValueGettable) []serverBuilder.Additio
 	return results
 }
 
+// serverNetworkInterfaceMaxCount サーバーに接続できるNICの最大数
+const serverNetworkInterfaceMaxCount = 10
+
+// serverManagedNICCount network_interfaceで管理するNICの数を返す
+//
+// recordedはstateに記録されたmanaged_network_interface_count、nicsはstateのnetwork_interface。
+// 管理するNICより後ろのNICはsakuracloud_server_network_interfaceなど他のリソースで管理されているものとして扱う。
+// managed_network_interface_countが記録される前に作成されたリソースの場合はnetwork_interfaceの全てを管理対象とする
+func serverManagedNICCount(recorded int, nics []interface{}, server *sacloud.Server) int {
+	count := recorded
+	if count == 0 {
+		count = len(nics)
+	}
+	if count > len(server.Interfaces) {
+		count = len(server.Interfaces)
+	}
+	return count
+}
+
+// expandServerUnmanagedNICs network_interfaceで管理していないNICを現在の設定のままbuilderへ追加する
+//
+// server builderはNICを順番で扱うため、他のリソースで管理されているNICがある場合はnetwork_interfaceの追加/削除はできない
+func expandServerUnmanagedNICs(d resourceValueChangeHandler, builder *serverBuilder.Builder, server *sacloud.Server, managed int) error {
+	if managed >= len(server.Interfaces) {
+		return nil
+	}
+	_, n := d.GetChange("network_interface")
+	if len(n.([]interface{})) != managed {
+		return fmt.Errorf(
+			"network_interface can't be added or removed while the Server has %d network interfaces managed by other resources",
+			len(server.Interfaces)-managed,
+		)
+	}
+
+	for i := managed; i < len(server.Interfaces); i++ {
+		nic := server.Interfaces[i]
+		switch {
+		case nic.SwitchID.IsEmpty():
+			if i == 0 {
+				builder.NIC = &serverBuilder.DisconnectedNICSetting{}
+				continue
+			}
+			builder.AdditionalNICs = append(builder.AdditionalNICs, &serverBuilder.DisconnectedNICSetting{})
+		case nic.SwitchScope == types.Scopes.Shared:
+			builder.NIC = &serverBuilder.SharedNICSetting{PacketFilterID: nic.PacketFilterID}
+		default:
+			setting := &serverBuilder.ConnectedNICSetting{
+				SwitchID:         nic.SwitchID,
+				PacketFilterID:   nic.PacketFilterID,
+				DisplayIPAddress: nic.UserIPAddress,
+			}
+			if i == 0 {
+				builder.NIC = setting
+				continue
+			}
+			builder.AdditionalNICs = append(builder.AdditionalNICs, setting)
+		}
+	}
+	return nil
+}
+
 func flattenServerNICs(server *sacloud.Server) []interface{} {
 	var results []interface{}
 	for _, nic := range server.Interfaces {
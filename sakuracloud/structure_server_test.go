@@ -17,6 +17,10 @@ package sakuracloud
 import (
 	"reflect"
 	"testing"
+
+	serverBuilder "github.com/sacloud/libsacloud/v2/helper/builder/server"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
 )
 
 type dummyResourceValueChangeHandler struct {
@@ -344,3 +348,133 @@ func TestStructureServer_isDiskEditParameterChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestStructureServer_serverManagedNICCount(t *testing.T) {
+	server := &sacloud.Server{
+		Interfaces: []*sacloud.InterfaceView{{ID: 1}, {ID: 2}, {ID: 3}},
+	}
+	nic := map[string]interface{}{"upstream": "shared"}
+
+	cases := []struct {
+		msg      string
+		recorded int
+		nics     []interface{}
+		expect   int
+	}{
+		{
+			msg:      "importing",
+			recorded: serverNetworkInterfaceMaxCount,
+			expect:   3,
+		},
+		{
+			msg:    "without network_interface",
+			expect: 0,
+		},
+		{
+			msg:      "some NICs are managed by other resources",
+			recorded: 1,
+			nics:     []interface{}{nic},
+			expect:   1,
+		},
+		{
+			msg:      "all NICs are managed",
+			recorded: 3,
+			nics:     []interface{}{nic, nic, nic},
+			expect:   3,
+		},
+		{
+			msg:      "NICs are removed outside",
+			recorded: 4,
+			nics:     []interface{}{nic, nic, nic, nic},
+			expect:   3,
+		},
+		{
+			msg:    "created before managed_network_interface_count is recorded",
+			nics:   []interface{}{nic, nic},
+			expect: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		got := serverManagedNICCount(tc.recorded, tc.nics, server)
+		if got != tc.expect {
+			t.Fatalf("got unexpected state: pattern: %s expected: %d actual: %d", tc.msg, tc.expect, got)
+		}
+	}
+}
+
+func TestStructureServer_expandServerUnmanagedNICs(t *testing.T) {
+	server := &sacloud.Server{
+		Interfaces: []*sacloud.InterfaceView{
+			{ID: 1, SwitchID: 1, SwitchScope: types.Scopes.Shared},
+			{ID: 2, SwitchID: 2, SwitchScope: types.Scopes.User, PacketFilterID: 3, UserIPAddress: "192.168.0.11"},
+			{ID: 3},
+		},
+	}
+	nic := map[string]interface{}{"upstream": "shared"}
+
+	cases := []struct {
+		msg        string
+		in         *dummyResourceValueChangeHandler
+		managed    int
+		err        bool
+		nic        serverBuilder.NICSettingHolder
+		additional []serverBuilder.AdditionalNICSettingHolder
+	}{
+		{
+			msg: "all NICs are managed",
+			in: &dummyResourceValueChangeHandler{
+				oldState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{nic, nic, nic}}),
+				newState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{nic, nic, nic}}),
+			},
+			managed: 3,
+		},
+		{
+			msg: "some NICs are managed by other resources",
+			in: &dummyResourceValueChangeHandler{
+				oldState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{nic}}),
+				newState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{nic}}),
+			},
+			managed: 1,
+			additional: []serverBuilder.AdditionalNICSettingHolder{
+				&serverBuilder.ConnectedNICSetting{SwitchID: 2, PacketFilterID: 3, DisplayIPAddress: "192.168.0.11"},
+				&serverBuilder.DisconnectedNICSetting{},
+			},
+		},
+		{
+			msg: "all NICs are managed by other resources",
+			in: &dummyResourceValueChangeHandler{
+				oldState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{}}),
+				newState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{}}),
+			},
+			nic: &serverBuilder.SharedNICSetting{},
+			additional: []serverBuilder.AdditionalNICSettingHolder{
+				&serverBuilder.ConnectedNICSetting{SwitchID: 2, PacketFilterID: 3, DisplayIPAddress: "192.168.0.11"},
+				&serverBuilder.DisconnectedNICSetting{},
+			},
+		},
+		{
+			msg: "network_interface is added",
+			in: &dummyResourceValueChangeHandler{
+				oldState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{nic}}),
+				newState: mapToResourceData(map[string]interface{}{"network_interface": []interface{}{nic, nic}}),
+			},
+			managed: 1,
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		builder := &serverBuilder.Builder{}
+		err := expandServerUnmanagedNICs(tc.in, builder, server, tc.managed)
+		if (err != nil) != tc.err {
+			t.Fatalf("got unexpected error: pattern: %s error: %v", tc.msg, err)
+		}
+		if !reflect.DeepEqual(builder.NIC, tc.nic) {
+			t.Fatalf("got unexpected NIC: pattern: %s expected: %#v actual: %#v", tc.msg, tc.nic, builder.NIC)
+		}
+		if !reflect.DeepEqual(builder.AdditionalNICs, tc.additional) {
+			t.Fatalf("got unexpected AdditionalNICs: pattern: %s expected: %#v actual: %#v", tc.msg, tc.additional, builder.AdditionalNICs)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/sacloud/libsacloud/v2/sacloud/search"
+)
+
+// 月額料金が設定されていないサービスクラスで利用する1ヶ月あたりの日数/時間
+const (
+	serviceClassDaysPerMonth  = 30
+	serviceClassHoursPerMonth = 24 * serviceClassDaysPerMonth
+)
+
+// findServiceClass keyで指定したフィールドがvalueと完全一致するサービスクラスを返す
+func findServiceClass(ctx context.Context, client *APIClient, zone, key, value string) (*sacloud.ServiceClass, error) {
+	serviceClassOp := sacloud.NewServiceClassOp(client)
+	res, err := serviceClassOp.Find(ctx, zone, &sacloud.FindCondition{
+		Filter: search.Filter{
+			search.Key(key): search.AndEqual(value),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not find SakuraCloud ServiceClass resources: %s", err)
+	}
+	for _, class := range res.ServiceClasses {
+		if (key == "ServiceClassPath" && class.ServiceClassPath == value) ||
+			(key == "ServiceClassName" && class.ServiceClassName == value) {
+			return class, nil
+		}
+	}
+	return nil, fmt.Errorf("SakuraCloud ServiceClass[%s=%s] is not found", key, value)
+}
+
+// estimateMonthlyPrice 1ヶ月あたりの料金を返す
+//
+// 月額料金が設定されていない場合は日額料金、時間料金の順に1ヶ月分に換算する
+func estimateMonthlyPrice(price *sacloud.Price) int {
+	switch {
+	case price == nil:
+		return 0
+	case price.Monthly > 0:
+		return price.Monthly
+	case price.Daily > 0:
+		return price.Daily * serviceClassDaysPerMonth
+	default:
+		return price.Hourly * serviceClassHoursPerMonth
+	}
+}
+
+func schemaDataSourceServiceClassPrice() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"base_price": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The base price in JPY",
+		},
+		"hourly_price": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The hourly price in JPY",
+		},
+		"daily_price": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The daily price in JPY",
+		},
+		"monthly_price": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The monthly price in JPY",
+		},
+	}
+}
+
+func flattenServiceClassPrice(price *sacloud.Price) map[string]interface{} {
+	if price == nil {
+		price = &sacloud.Price{}
+	}
+	return map[string]interface{}{
+		"base_price":    price.Base,
+		"hourly_price":  price.Hourly,
+		"daily_price":   price.Daily,
+		"monthly_price": price.Monthly,
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"testing"
+
+	"github.com/sacloud/libsacloud/v2/sacloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateMonthlyPrice(t *testing.T) {
+	cases := []struct {
+		msg    string
+		in     *sacloud.Price
+		expect int
+	}{
+		{
+			msg:    "nil",
+			in:     nil,
+			expect: 0,
+		},
+		{
+			msg:    "monthly",
+			in:     &sacloud.Price{Hourly: 10, Daily: 108, Monthly: 2139},
+			expect: 2139,
+		},
+		{
+			msg:    "daily",
+			in:     &sacloud.Price{Hourly: 10, Daily: 108},
+			expect: 108 * 30,
+		},
+		{
+			msg:    "hourly",
+			in:     &sacloud.Price{Hourly: 10},
+			expect: 10 * 24 * 30,
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, estimateMonthlyPrice(tc.in), tc.msg)
+	}
+}
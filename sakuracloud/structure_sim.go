@@ -53,7 +53,7 @@ func expandSIMBuilder(d resourceValueGettable, client *APIClient) *simBuilder.Bu
 	return &simBuilder.Builder{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
-		Tags:        expandTags(d),
+		Tags:        expandTags(d, client),
 		IconID:      expandSakuraCloudID(d, "icon_id"),
 		ICCID:       d.Get("iccid").(string),
 		PassCode:    d.Get("passcode").(string),
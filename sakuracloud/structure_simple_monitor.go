@@ -20,7 +20,7 @@ import (
 	"github.com/sacloud/libsacloud/v2/sacloud/types"
 )
 
-func expandSimpleMonitorCreateRequest(d *schema.ResourceData) *sacloud.SimpleMonitorCreateRequest {
+func expandSimpleMonitorCreateRequest(d *schema.ResourceData, client *APIClient) *sacloud.SimpleMonitorCreateRequest {
 	return &sacloud.SimpleMonitorCreateRequest{
 		Target:             d.Get("target").(string),
 		Enabled:            types.StringFlag(d.Get("enabled").(bool)),
@@ -32,12 +32,12 @@ func expandSimpleMonitorCreateRequest(d *schema.ResourceData) *sacloud.SimpleMon
 		SlackWebhooksURL:   d.Get("notify_slack_webhook").(string),
 		NotifyInterval:     expandSimpleMonitorNotifyInterval(d),
 		Description:        d.Get("description").(string),
-		Tags:               expandTags(d),
+		Tags:               expandTags(d, client),
 		IconID:             expandSakuraCloudID(d, "icon_id"),
 	}
 }
 
-func expandSimpleMonitorUpdateRequest(d *schema.ResourceData) *sacloud.SimpleMonitorUpdateRequest {
+func expandSimpleMonitorUpdateRequest(d *schema.ResourceData, client *APIClient) *sacloud.SimpleMonitorUpdateRequest {
 	return &sacloud.SimpleMonitorUpdateRequest{
 		Enabled:            types.StringFlag(d.Get("enabled").(bool)),
 		HealthCheck:        expandSimpleMonitorHealthCheck(d),
@@ -48,7 +48,7 @@ func expandSimpleMonitorUpdateRequest(d *schema.ResourceData) *sacloud.SimpleMon
 		SlackWebhooksURL:   d.Get("notify_slack_webhook").(string),
 		NotifyInterval:     expandSimpleMonitorNotifyInterval(d),
 		Description:        d.Get("description").(string),
-		Tags:               expandTags(d),
+		Tags:               expandTags(d, client),
 		IconID:             expandSakuraCloudID(d, "icon_id"),
 	}
 }
@@ -0,0 +1,231 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sacloud/libsacloud/v2/sacloud/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	cases := []struct {
+		msg         string
+		tags        types.Tags
+		defaultTags types.Tags
+		expect      types.Tags
+	}{
+		{
+			msg:         "empty",
+			tags:        nil,
+			defaultTags: nil,
+			expect:      types.Tags{},
+		},
+		{
+			msg:         "without default tags",
+			tags:        types.Tags{"tag2", "tag1"},
+			defaultTags: nil,
+			expect:      types.Tags{"tag1", "tag2"},
+		},
+		{
+			msg:         "merged",
+			tags:        types.Tags{"tag1", "env=prod"},
+			defaultTags: types.Tags{"env=prod", "team=x"},
+			expect:      types.Tags{"env=prod", "tag1", "team=x"},
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, mergeDefaultTags(tc.tags, tc.defaultTags), tc.msg)
+	}
+}
+
+func TestExcludeDefaultTags(t *testing.T) {
+	cases := []struct {
+		msg            string
+		tags           types.Tags
+		defaultTags    types.Tags
+		configuredTags types.Tags
+		expect         types.Tags
+	}{
+		{
+			msg:         "without default tags",
+			tags:        types.Tags{"tag1", "tag2"},
+			defaultTags: nil,
+			expect:      types.Tags{"tag1", "tag2"},
+		},
+		{
+			msg:         "default tags are excluded",
+			tags:        types.Tags{"env=prod", "tag1", "team=x"},
+			defaultTags: types.Tags{"env=prod", "team=x"},
+			expect:      types.Tags{"tag1"},
+		},
+		{
+			msg:            "configured tags are kept",
+			tags:           types.Tags{"env=prod", "tag1", "team=x"},
+			defaultTags:    types.Tags{"env=prod", "team=x"},
+			configuredTags: types.Tags{"env=prod", "tag1"},
+			expect:         types.Tags{"env=prod", "tag1"},
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, excludeDefaultTags(tc.tags, tc.defaultTags, tc.configuredTags), tc.msg)
+	}
+}
+
+func TestExcludeIgnoredTags(t *testing.T) {
+	ignore := &ignoreTags{
+		keys:        []string{"managed-by"},
+		keyPrefixes: []string{"@auto-"},
+	}
+
+	cases := []struct {
+		msg            string
+		tags           types.Tags
+		configuredTags types.Tags
+		expect         types.Tags
+	}{
+		{
+			msg:    "ignored tags are excluded",
+			tags:   types.Tags{"@auto-reboot", "managed-by=x", "tag1"},
+			expect: types.Tags{"tag1"},
+		},
+		{
+			msg:            "configured tags are kept",
+			tags:           types.Tags{"@auto-reboot", "managed-by=x", "tag1"},
+			configuredTags: types.Tags{"@auto-reboot", "tag1"},
+			expect:         types.Tags{"@auto-reboot", "tag1"},
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, excludeIgnoredTags(tc.tags, ignore, tc.configuredTags), tc.msg)
+	}
+}
+
+func TestExpandTags(t *testing.T) {
+	client := &APIClient{
+		defaultTags: types.Tags{"env=prod"},
+		ignoreTags:  &ignoreTags{keyPrefixes: []string{"@auto-"}},
+	}
+
+	cases := []struct {
+		msg    string
+		in     map[string]interface{}
+		expect types.Tags
+	}{
+		{
+			msg:    "tags_all is empty",
+			in:     map[string]interface{}{"tags": []interface{}{"tag1"}},
+			expect: types.Tags{"env=prod", "tag1"},
+		},
+		{
+			msg: "ignored tags in current tags_all are kept",
+			in: map[string]interface{}{
+				"tags":     []interface{}{"tag1"},
+				"tags_all": []interface{}{"@auto-reboot", "env=prod", "removed", "tag1"},
+			},
+			expect: types.Tags{"@auto-reboot", "env=prod", "tag1"},
+		},
+		{
+			msg: "all tags are removed",
+			in: map[string]interface{}{
+				"tags_all": []interface{}{"env=prod", "tag1"},
+			},
+			expect: types.Tags{"env=prod"},
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			"tags":     schemaResourceTags("test"),
+			"tags_all": schemaResourceTagsAll("test"),
+		}, tc.in)
+		assert.Equal(t, tc.expect, expandTags(d, client), tc.msg)
+	}
+}
+
+func TestParseZonedID(t *testing.T) {
+	cases := []struct {
+		in    string
+		zone  string
+		id    string
+		zoned bool
+	}{
+		{in: "is1a/123456789012", zone: "is1a", id: "123456789012", zoned: true},
+		{in: "is1a:123456789012", zone: "is1a", id: "123456789012", zoned: true},
+		{in: "123456789012", zone: "", id: "123456789012", zoned: false},
+		{in: "/123456789012", zone: "", id: "/123456789012", zoned: false},
+		{in: "is1a/", zone: "", id: "is1a/", zoned: false},
+	}
+
+	for _, tc := range cases {
+		zone, id, zoned := parseZonedID(tc.in)
+		assert.Equal(t, tc.zone, zone, tc.in)
+		assert.Equal(t, tc.id, id, tc.in)
+		assert.Equal(t, tc.zoned, zoned, tc.in)
+	}
+}
+
+func TestImportZonedResourceStateContext(t *testing.T) {
+	client := &APIClient{defaultZone: "is1a", zones: []string{"is1a", "tk1a"}}
+	resourceSchema := map[string]*schema.Schema{
+		"zone": {Type: schema.TypeString, Optional: true, Computed: true},
+	}
+	found := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return nil
+	}
+	notFound := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		d.SetId("")
+		return nil
+	}
+
+	cases := []struct {
+		msg    string
+		id     string
+		read   schema.ReadContextFunc
+		err    bool
+		expect string
+		zone   string
+	}{
+		{msg: "id only", id: "123456789012", read: notFound, expect: "123456789012", zone: ""},
+		{msg: "zone/id", id: "tk1a/123456789012", read: found, expect: "123456789012", zone: "tk1a"},
+		{msg: "zone:id", id: "tk1a:123456789012", read: found, expect: "123456789012", zone: "tk1a"},
+		{msg: "invalid zone", id: "tk1b/123456789012", read: found, err: true},
+		{msg: "not found", id: "tk1a/123456789012", read: notFound, err: true},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+		d.SetId(tc.id)
+
+		res, err := importZonedResourceStateContext("Switch", tc.read)(context.Background(), d, client)
+		if tc.err {
+			assert.Error(t, err, tc.msg)
+			continue
+		}
+		if !assert.NoError(t, err, tc.msg) {
+			continue
+		}
+		assert.Len(t, res, 1, tc.msg)
+		assert.Equal(t, tc.expect, res[0].Id(), tc.msg)
+		assert.Equal(t, tc.zone, res[0].Get("zone"), tc.msg)
+	}
+}
@@ -31,7 +31,7 @@ func expandVPCRouterBuilder(d resourceValueGettable, client *APIClient) *vpcrout
 	return &vpcrouter.Builder{
 		Name:                  d.Get("name").(string),
 		Description:           d.Get("description").(string),
-		Tags:                  expandTags(d),
+		Tags:                  expandTags(d, client),
 		IconID:                expandSakuraCloudID(d, "icon_id"),
 		PlanID:                expandVPCRouterPlanID(d),
 		Version:               d.Get("version").(int),
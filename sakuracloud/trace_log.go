@@ -0,0 +1,278 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// トレースログの出力フォーマット
+const (
+	traceFormatText = "text"
+	traceFormatJSON = "json"
+)
+
+var traceFormats = []string{traceFormatText, traceFormatJSON}
+
+const (
+	traceLogPrefix    = "[TRACE]"
+	traceRedactedText = "******"
+)
+
+// traceSensitiveAPIFields スキーマのSensitiveフラグに加えてマスクするAPIのフィールド名
+var traceSensitiveAPIFields = []string{
+	"AccessTokenSecret",
+	"BasicAuthPassword",
+	"PassCode",
+	"Password",
+	"PreSharedSecret",
+	"PrivateKey",
+	"ReplicaPassword",
+	"SecretKey",
+	"SecretKeys",
+	"SharedKey",
+	"SourceSharedKey",
+	"UserPassword",
+}
+
+// traceSensitiveHeaderPattern マスクするHTTPヘッダ
+var traceSensitiveHeaderPattern = regexp.MustCompile(`(?mi)^(Authorization|Proxy-Authorization|Cookie|Set-Cookie):[^\r\n]*`)
+
+// collectSensitiveKeys リソース/データソースのスキーマからSensitiveな項目のキーを収集する
+func collectSensitiveKeys(resources ...map[string]*schema.Resource) []string {
+	keys := make(map[string]bool)
+	var collect func(map[string]*schema.Schema)
+	collect = func(s map[string]*schema.Schema) {
+		for k, v := range s {
+			if v.Sensitive {
+				keys[k] = true
+			}
+			if r, ok := v.Elem.(*schema.Resource); ok {
+				collect(r.Schema)
+			}
+		}
+	}
+	for _, m := range resources {
+		for _, r := range m {
+			collect(r.Schema)
+		}
+	}
+
+	var results []string
+	for k := range keys {
+		results = append(results, k)
+	}
+	sort.Strings(results)
+	return results
+}
+
+// traceRedactor トレースログ中の秘匿情報をマスクする
+type traceRedactor struct {
+	keys map[string]bool
+}
+
+func newTraceRedactor(sensitiveKeys []string) *traceRedactor {
+	r := &traceRedactor{keys: make(map[string]bool)}
+	for _, k := range append(traceSensitiveAPIFields, sensitiveKeys...) {
+		r.keys[normalizeTraceKey(k)] = true
+	}
+	return r
+}
+
+// normalizeTraceKey スキーマのキー(snake_case)とAPIのフィールド名(CamelCase)を比較できるように正規化する
+func normalizeTraceKey(key string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+}
+
+// traceMessage マスク済みのトレースログのメッセージ
+type traceMessage struct {
+	text string      // メッセージ全体
+	data interface{} // メッセージに含まれていたJSON。複数含まれていた場合はそれらのスライス
+	rest string      // メッセージからJSONを除いた部分
+}
+
+// redact メッセージ中のHTTPヘッダとJSONに含まれる秘匿情報をマスクする
+//
+// メッセージ中に複数のJSONが含まれる場合(リクエストとレスポンスのボディなど)はその全てをマスクする
+func (r *traceRedactor) redact(msg string) *traceMessage {
+	msg = traceSensitiveHeaderPattern.ReplaceAllString(msg, "$1: "+traceRedactedText)
+
+	var text, rest strings.Builder
+	var values []interface{}
+	pos := 0
+	for pos < len(msg) {
+		i := strings.IndexAny(msg[pos:], "{[")
+		if i < 0 {
+			break
+		}
+		start := pos + i
+
+		v, data, end, ok := r.redactJSON(msg[start:])
+		if !ok {
+			// JSONとして解釈できない括弧は読み飛ばす
+			text.WriteString(msg[pos : start+1])
+			rest.WriteString(msg[pos : start+1])
+			pos = start + 1
+			continue
+		}
+
+		text.WriteString(msg[pos:start])
+		text.Write(data)
+		rest.WriteString(msg[pos:start])
+		rest.WriteString(" ")
+		values = append(values, v)
+		pos = start + end
+	}
+	text.WriteString(msg[pos:])
+	rest.WriteString(msg[pos:])
+
+	result := &traceMessage{text: text.String(), rest: msg}
+	switch len(values) {
+	case 0:
+		return result
+	case 1:
+		result.data = values[0]
+	default:
+		result.data = values
+	}
+	result.rest = strings.Join(strings.Fields(rest.String()), " ")
+	return result
+}
+
+// redactJSON 先頭のJSONをデコードしてマスクし、マスク済みの値とそのJSON、元のJSONの終端位置を返す
+func (r *traceRedactor) redactJSON(s string) (interface{}, []byte, int, bool) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, nil, 0, false
+	}
+	v = r.redactValue(v)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, 0, false
+	}
+	return v, data, int(dec.InputOffset()), true
+}
+
+func (r *traceRedactor) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if r.keys[normalizeTraceKey(k)] && value != nil && value != "" {
+				v[k] = traceRedactedText
+				continue
+			}
+			v[k] = r.redactValue(value)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = r.redactValue(v[i])
+		}
+	}
+	return v
+}
+
+// traceLogWriter logパッケージの出力先として設定し、[TRACE]ログをマスクした上で指定のフォーマット/出力先へ書き込む
+//
+// [TRACE]以外のログは元の出力先へそのまま書き込む
+type traceLogWriter struct {
+	mu       sync.Mutex
+	out      io.Writer
+	traceOut io.Writer
+	format   string
+	redactor *traceRedactor
+}
+
+// setupTraceLog logパッケージの出力先をtraceLogWriterに差し替える
+func (c *Config) setupTraceLog() error {
+	format := c.TraceFormat
+	if format == "" {
+		format = traceFormatText
+	}
+	if format != traceFormatText && format != traceFormatJSON {
+		return fmt.Errorf("trace_format must be one of %q, got %s", traceFormats, format)
+	}
+
+	out := log.Writer()
+	if w, ok := out.(*traceLogWriter); ok {
+		out = w.out
+	}
+	traceOut := out
+	if c.TraceFilePath != "" {
+		f, err := os.OpenFile(c.TraceFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("opening trace file %q is failed: %s", c.TraceFilePath, err)
+		}
+		traceOut = f
+	}
+
+	log.SetOutput(&traceLogWriter{
+		out:      out,
+		traceOut: traceOut,
+		format:   format,
+		redactor: newTraceRedactor(c.sensitiveKeys),
+	})
+	return nil
+}
+
+func (w *traceLogWriter) Write(p []byte) (int, error) {
+	line := string(p)
+	i := strings.Index(line, traceLogPrefix)
+	if i < 0 {
+		return w.out.Write(p)
+	}
+
+	msg := w.redactor.redact(strings.TrimSpace(line[i+len(traceLogPrefix):]))
+
+	var buf bytes.Buffer
+	switch w.format {
+	case traceFormatJSON:
+		entry := map[string]interface{}{
+			"@timestamp": time.Now().Format(time.RFC3339Nano),
+			"@level":     "trace",
+			"@message":   msg.text,
+		}
+		if msg.data != nil {
+			// "args: {...}"のようにJSONを含む場合はメッセージとデータを分けて出力する
+			entry["@message"] = strings.TrimSuffix(msg.rest, ":")
+			entry["data"] = msg.data
+		}
+		if err := json.NewEncoder(&buf).Encode(entry); err != nil {
+			return 0, err
+		}
+	default:
+		buf.WriteString(line[:i] + traceLogPrefix + " " + msg.text + "\n")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.traceOut.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectSensitiveKeys(t *testing.T) {
+	p := Provider()
+	keys := collectSensitiveKeys(p.ResourcesMap, p.DataSourcesMap)
+
+	assert.Contains(t, keys, "password")
+	assert.Contains(t, keys, "pre_shared_secret")
+	assert.NotContains(t, keys, "name")
+}
+
+func TestTraceRedactor_redact(t *testing.T) {
+	redactor := newTraceRedactor([]string{"pre_shared_secret"})
+
+	cases := []struct {
+		msg    string
+		in     string
+		expect string
+	}{
+		{
+			msg:    "without JSON",
+			in:     "DiskAPI.Config start",
+			expect: "DiskAPI.Config start",
+		},
+		{
+			msg:    "nested fields",
+			in:     `args: {"Argzone":"is1a","Argedit":{"Password":"p@ssw0rd","HostName":"foo","UserSubnet":{"DefaultRoute":"192.0.2.1"}}}`,
+			expect: `args: {"Argedit":{"HostName":"foo","Password":"******","UserSubnet":{"DefaultRoute":"192.0.2.1"}},"Argzone":"is1a"}`,
+		},
+		{
+			msg:    "fields in array",
+			in:     `results: {"Settings":{"Router":{"SiteToSiteIPsecVPN":[{"PreSharedSecret":"secret","Peer":"192.0.2.2"}]}}}`,
+			expect: `results: {"Settings":{"Router":{"SiteToSiteIPsecVPN":[{"Peer":"192.0.2.2","PreSharedSecret":"******"}]}}}`,
+		},
+		{
+			msg:    "empty value",
+			in:     `args: {"Password":""}`,
+			expect: `args: {"Password":""}`,
+		},
+		{
+			msg:    "http headers",
+			in:     "request: PUT https://example.com/\r\nAuthorization: Basic dG9rZW46c2VjcmV0\r\n\r\n{\"Disk\":{\"Password\":\"p@ssw0rd\"}}\n===",
+			expect: "request: PUT https://example.com/\r\nAuthorization: ******\r\n\r\n{\"Disk\":{\"Password\":\"******\"}}\n===",
+		},
+		{
+			msg:    "multiple JSON values",
+			in:     `request: {"Disk":{"Password":"p@ssw0rd"}} response: {"Disk":{"ID":"1","Password":"p@ssw0rd"}}`,
+			expect: `request: {"Disk":{"Password":"******"}} response: {"Disk":{"ID":"1","Password":"******"}}`,
+		},
+		{
+			msg:    "brackets which are not JSON",
+			in:     `[is1a] {not json} args: {"Password":"p@ssw0rd"}`,
+			expect: `[is1a] {not json} args: {"Password":"******"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expect, redactor.redact(tc.in).text, tc.msg)
+	}
+}
+
+func TestTraceLogWriter(t *testing.T) {
+	for _, format := range traceFormats {
+		out := bytes.NewBufferString("")
+		traceOut := bytes.NewBufferString("")
+		w := &traceLogWriter{
+			out:      out,
+			traceOut: traceOut,
+			format:   format,
+			redactor: newTraceRedactor(nil),
+		}
+
+		_, err := w.Write([]byte("2021/01/01 00:00:00 [DEBUG] foobar\n"))
+		assert.NoError(t, err, format)
+		_, err = w.Write([]byte("2021/01/01 00:00:00 [TRACE] \targs: {\"Password\":\"p@ssw0rd\"}\n"))
+		assert.NoError(t, err, format)
+
+		assert.Equal(t, "2021/01/01 00:00:00 [DEBUG] foobar\n", out.String(), format)
+		assert.NotContains(t, traceOut.String(), "p@ssw0rd", format)
+
+		switch format {
+		case traceFormatJSON:
+			var entry map[string]interface{}
+			if !assert.NoError(t, json.Unmarshal(traceOut.Bytes(), &entry), format) {
+				continue
+			}
+			assert.Equal(t, "args", entry["@message"], format)
+			assert.Equal(t, map[string]interface{}{"Password": "******"}, entry["data"], format)
+		default:
+			assert.Equal(t, "2021/01/01 00:00:00 [TRACE] args: {\"Password\":\"******\"}\n", traceOut.String(), format)
+		}
+	}
+}
+
+func TestTraceLogWriter_multipleJSONValues(t *testing.T) {
+	traceOut := bytes.NewBufferString("")
+	w := &traceLogWriter{
+		out:      bytes.NewBufferString(""),
+		traceOut: traceOut,
+		format:   traceFormatJSON,
+		redactor: newTraceRedactor(nil),
+	}
+
+	_, err := w.Write([]byte("2021/01/01 00:00:00 [TRACE] request: {\"Password\":\"p@ssw0rd\"} response: {\"Password\":\"p@ssw0rd\"}\n"))
+	assert.NoError(t, err)
+	assert.NotContains(t, traceOut.String(), "p@ssw0rd")
+
+	var entry map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal(traceOut.Bytes(), &entry)) {
+		return
+	}
+	assert.Equal(t, "request: response", entry["@message"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"Password": "******"},
+		map[string]interface{}{"Password": "******"},
+	}, entry["data"])
+}
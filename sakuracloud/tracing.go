@@ -0,0 +1,200 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OpenTelemetryのエクスポーター
+const (
+	otelExporterOTLP = "otlp"
+	otelExporterFile = "file"
+)
+
+var otelExporters = []string{otelExporterOTLP, otelExporterFile}
+
+const (
+	tracerName = "github.com/sacloud/terraform-provider-sakuracloud"
+
+	// otelDefaultEndpoint otel_endpoint未指定時のOTLP/HTTPの送信先
+	otelDefaultEndpoint = "localhost:4318"
+
+	// tracerShutdownTimeout プロバイダー終了時にスパンを送信する際のタイムアウト
+	//
+	// Terraformはプラグインの停止要求から2秒で強制終了するため、それより短くしておく
+	tracerShutdownTimeout = 1500 * time.Millisecond
+)
+
+// スパンに付与する属性のキー
+const (
+	traceAttrResourceType = "terraform.resource.type"
+	traceAttrOperation    = "terraform.operation"
+	traceAttrZone         = "sakuracloud.zone"
+)
+
+// tracerShutdownFuncs プロバイダー終了時に呼び出す、TracerProviderごとのシャットダウン処理
+var tracerShutdownFuncs = &struct {
+	mu    sync.Mutex
+	funcs []func(context.Context) error
+}{}
+
+// initTracerProvider OpenTelemetryのTracerProviderを初期化する
+//
+// エクスポーターが指定されていない場合はnilを返す。
+// スパンはバッチでエクスポートされ、ShutdownTracerProvidersの呼び出し時に未送信のスパンが送信される
+func (c *Config) initTracerProvider() (*sdktrace.TracerProvider, error) {
+	exporter, err := c.newTraceExporter()
+	if err != nil || exporter == nil {
+		return nil, err
+	}
+
+	// note: TracerProvider.Shutdownはエラーの有無に関わらずotel.Handleを呼び出しnilをログ出力してしまうため、
+	//       sdktrace.WithBatcherと同じBatchSpanProcessorを直接保持してシャットダウンする
+	processor := sdktrace.NewBatchSpanProcessor(exporter)
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.ServiceNameKey.String("terraform-provider-sakuracloud"),
+			semconv.ServiceVersionKey.String(Version),
+		)),
+	)
+
+	tracerShutdownFuncs.mu.Lock()
+	tracerShutdownFuncs.funcs = append(tracerShutdownFuncs.funcs, func(ctx context.Context) error {
+		if err := processor.Shutdown(ctx); err != nil {
+			return err
+		}
+		return exporter.Shutdown(ctx)
+	})
+	tracerShutdownFuncs.mu.Unlock()
+
+	return tp, nil
+}
+
+func (c *Config) newTraceExporter() (export.SpanExporter, error) {
+	switch c.OTelExporter {
+	case "":
+		return nil, nil
+	case otelExporterOTLP:
+		endpoint := c.OTelEndpoint
+		if endpoint == "" {
+			endpoint = otelDefaultEndpoint
+		}
+		return newOTLPHTTPExporter(endpoint, c.OTelInsecure), nil
+	case otelExporterFile:
+		if c.OTelFilePath == "" {
+			return nil, fmt.Errorf("otel_file_path is required when otel_exporter is %q", otelExporterFile)
+		}
+		return newFileExporter(c.OTelFilePath)
+	default:
+		return nil, fmt.Errorf("otel_exporter must be one of %q, got %s", otelExporters, c.OTelExporter)
+	}
+}
+
+// ShutdownTracerProviders 初期化済みのTracerProviderをシャットダウンし、未送信のスパンを送信する
+//
+// プラグインのサーバーが停止した後に呼び出す
+func ShutdownTracerProviders() {
+	tracerShutdownFuncs.mu.Lock()
+	funcs := tracerShutdownFuncs.funcs
+	tracerShutdownFuncs.funcs = nil
+	tracerShutdownFuncs.mu.Unlock()
+
+	if len(funcs) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+	defer cancel()
+	for _, shutdown := range funcs {
+		if err := shutdown(ctx); err != nil {
+			log.Printf("[WARN] shutting down tracer provider is failed: %s", err)
+		}
+	}
+}
+
+// tracer TracerProviderが初期化されていない場合はno-opのTracerを返す
+func (c *APIClient) tracer() trace.Tracer {
+	if c == nil || c.tracerProvider == nil {
+		return trace.NewNoopTracerProvider().Tracer(tracerName)
+	}
+	return c.tracerProvider.Tracer(tracerName)
+}
+
+// traceResources 各リソース/データソースのCRUD処理にリソースタイプ/操作/ゾーンを属性に持つスパンを付与する
+//
+// TracerProviderが初期化されていない場合はno-opのスパンとなる
+func traceResources(resources map[string]*schema.Resource, dataSource bool) {
+	for resourceType, r := range resources {
+		_, zoned := r.Schema["zone"]
+		r.ReadContext = schema.ReadContextFunc(traceCRUDFunc(crudContextFunc(r.ReadContext), resourceType, "read", zoned))
+		if dataSource {
+			continue
+		}
+		r.CreateContext = schema.CreateContextFunc(traceCRUDFunc(crudContextFunc(r.CreateContext), resourceType, "create", zoned))
+		r.UpdateContext = schema.UpdateContextFunc(traceCRUDFunc(crudContextFunc(r.UpdateContext), resourceType, "update", zoned))
+		r.DeleteContext = schema.DeleteContextFunc(traceCRUDFunc(crudContextFunc(r.DeleteContext), resourceType, "delete", zoned))
+	}
+}
+
+type crudContextFunc func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+func traceCRUDFunc(f crudContextFunc, resourceType, operation string, zoned bool) crudContextFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		attrs := []label.KeyValue{
+			label.String(traceAttrResourceType, resourceType),
+			label.String(traceAttrOperation, operation),
+		}
+		client, _ := meta.(*APIClient)
+		if client != nil && zoned {
+			attrs = append(attrs, label.String(traceAttrZone, getZone(d, client)))
+		}
+
+		ctx, span := client.tracer().Start(ctx, fmt.Sprintf("%s.%s", resourceType, operation), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		diags := f(ctx, d, meta)
+		if diags.HasError() {
+			for _, d := range diags {
+				if d.Severity == diag.Error {
+					span.SetStatus(codes.Error, d.Summary)
+					break
+				}
+			}
+		} else {
+			span.SetStatus(codes.Ok, "")
+		}
+		return diags
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+)
+
+const otlpTracesPath = "/v1/traces"
+
+// otlpHTTPExporter OTLP/HTTPのJSONエンコーディングでスパンを送信するエクスポーター
+//
+// gRPC版のOTLPエクスポーターは依存モジュールが大きいため、HTTP+JSONのみを実装している。
+// ヘッダの追加、gzip圧縮、送信失敗時のリトライには対応していない
+type otlpHTTPExporter struct {
+	url    string
+	client *http.Client
+}
+
+func newOTLPHTTPExporter(endpoint string, insecure bool) *otlpHTTPExporter {
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+	return &otlpHTTPExporter{
+		url:    fmt.Sprintf("%s://%s%s", scheme, endpoint, otlpTracesPath),
+		client: &http.Client{},
+	}
+}
+
+func (e *otlpHTTPExporter) ExportSpans(ctx context.Context, spans []*export.SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(otlpTracesRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("exporting spans to %q is failed: %s", e.url, err)
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body) // nolint
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("exporting spans to %q is failed: %s", e.url, res.Status)
+	}
+	return nil
+}
+
+func (e *otlpHTTPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// fileExporter スパンを1行1スパンのJSONとしてファイルへ書き込むエクスポーター
+type fileExporter struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func newFileExporter(path string) (*fileExporter, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening trace file %q is failed: %s", path, err)
+	}
+	return &fileExporter{f: f}, nil
+}
+
+func (e *fileExporter) ExportSpans(ctx context.Context, spans []*export.SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	enc := json.NewEncoder(e.f)
+	for _, span := range spans {
+		if err := enc.Encode(otlpSpan(span)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.f.Close()
+}
+
+// otlpTracesRequest スパンをOTLPのExportTraceServiceRequestのJSON表現へ変換する
+func otlpTracesRequest(spans []*export.SpanData) map[string]interface{} {
+	var resourceSpans []interface{}
+	indexes := make(map[label.Distinct]int)
+	for _, span := range spans {
+		key := span.Resource.Equivalent()
+		idx, ok := indexes[key]
+		if !ok {
+			idx = len(resourceSpans)
+			indexes[key] = idx
+			resourceSpans = append(resourceSpans, map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes(span.Resource.Attributes()),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{
+							"name":    span.InstrumentationLibrary.Name,
+							"version": span.InstrumentationLibrary.Version,
+						},
+						"spans": []interface{}{},
+					},
+				},
+			})
+		}
+		scope := resourceSpans[idx].(map[string]interface{})["scopeSpans"].([]interface{})[0].(map[string]interface{})
+		scope["spans"] = append(scope["spans"].([]interface{}), otlpSpan(span))
+	}
+	return map[string]interface{}{"resourceSpans": resourceSpans}
+}
+
+// otlpSpan スパンをOTLPのSpanのJSON表現へ変換する
+func otlpSpan(span *export.SpanData) map[string]interface{} {
+	v := map[string]interface{}{
+		"traceId":           span.SpanContext.TraceID.String(),
+		"spanId":            span.SpanContext.SpanID.String(),
+		"name":              span.Name,
+		"kind":              int(span.SpanKind),
+		"startTimeUnixNano": strconv.FormatInt(span.StartTime.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+		"attributes":        otlpAttributes(span.Attributes),
+		"status":            otlpStatus(span.StatusCode, span.StatusMessage),
+	}
+	if span.ParentSpanID.IsValid() {
+		v["parentSpanId"] = span.ParentSpanID.String()
+	}
+
+	var events []interface{}
+	for _, event := range span.MessageEvents {
+		events = append(events, map[string]interface{}{
+			"name":         event.Name,
+			"timeUnixNano": strconv.FormatInt(event.Time.UnixNano(), 10),
+			"attributes":   otlpAttributes(event.Attributes),
+		})
+	}
+	if len(events) > 0 {
+		v["events"] = events
+	}
+	return v
+}
+
+// otlpStatus OTLPのStatusのJSON表現を返す
+//
+// OTLPのステータスコードはUNSET=0/OK=1/ERROR=2でありcodes.Codeとは値が異なる
+func otlpStatus(code codes.Code, message string) map[string]interface{} {
+	status := map[string]interface{}{"code": 0}
+	switch code {
+	case codes.Ok:
+		status["code"] = 1
+	case codes.Error:
+		status["code"] = 2
+		status["message"] = message
+	}
+	return status
+}
+
+func otlpAttributes(attrs []label.KeyValue) []interface{} {
+	results := []interface{}{}
+	for _, attr := range attrs {
+		var value map[string]interface{}
+		switch attr.Value.Type() {
+		case label.BOOL:
+			value = map[string]interface{}{"boolValue": attr.Value.AsBool()}
+		case label.INT32, label.INT64, label.UINT32:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(attr.Value.AsInt64(), 10)}
+		case label.FLOAT32:
+			value = map[string]interface{}{"doubleValue": float64(attr.Value.AsFloat32())}
+		case label.FLOAT64:
+			value = map[string]interface{}{"doubleValue": attr.Value.AsFloat64()}
+		default:
+			value = map[string]interface{}{"stringValue": attr.Value.Emit()}
+		}
+		results = append(results, map[string]interface{}{
+			"key":   string(attr.Key),
+			"value": value,
+		})
+	}
+	return results
+}
@@ -0,0 +1,280 @@
+// Copyright 2016-2021 terraform-provider-sakuracloud authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sakuracloud
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestConfig_initTracerProvider(t *testing.T) {
+	tp, err := (&Config{}).initTracerProvider()
+	assert.NoError(t, err)
+	assert.Nil(t, tp)
+
+	_, err = (&Config{OTelExporter: "unknown"}).initTracerProvider()
+	assert.Error(t, err)
+
+	_, err = (&Config{OTelExporter: otelExporterFile}).initTracerProvider()
+	assert.Error(t, err)
+}
+
+func testTraceResources(t *testing.T, client *APIClient) {
+	resources := map[string]*schema.Resource{
+		"sakuracloud_switch": {
+			Schema: map[string]*schema.Schema{
+				"zone": {Type: schema.TypeString, Optional: true},
+			},
+			ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+				return nil
+			},
+		},
+	}
+	traceResources(resources, false)
+
+	r := resources["sakuracloud_switch"]
+	assert.Nil(t, r.CreateContext)
+	assert.Nil(t, r.DeleteContext)
+
+	diags := r.ReadContext(context.Background(), r.TestResourceData(), client)
+	assert.False(t, diags.HasError())
+}
+
+func TestTraceResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	tp, err := (&Config{OTelExporter: otelExporterFile, OTelFilePath: path}).initTracerProvider()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testTraceResources(t, &APIClient{defaultZone: "is1a", tracerProvider: tp})
+
+	// バッチでエクスポートされるため、シャットダウンするまでは書き込まれない
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+
+	ShutdownTracerProviders()
+
+	data, err = ioutil.ReadFile(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if !assert.Len(t, lines, 1) {
+		return
+	}
+	var span map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal([]byte(lines[0]), &span)) {
+		return
+	}
+	assert.Equal(t, "sakuracloud_switch.read", span["name"])
+	assert.Equal(t, map[string]interface{}{"code": float64(1)}, span["status"])
+	assert.Contains(t, span["attributes"], map[string]interface{}{
+		"key":   traceAttrZone,
+		"value": map[string]interface{}{"stringValue": "is1a"},
+	})
+}
+
+func TestTraceResources_withoutTracerProvider(t *testing.T) {
+	testTraceResources(t, &APIClient{defaultZone: "is1a"})
+	testTraceResources(t, nil)
+}
+
+func TestOTLPHTTPExporter(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, otlpTracesPath, r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	tp, err := (&Config{
+		OTelExporter: otelExporterOTLP,
+		OTelEndpoint: strings.TrimPrefix(server.URL, "http://"),
+		OTelInsecure: true,
+	}).initTracerProvider()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	testTraceResources(t, &APIClient{defaultZone: "is1a", tracerProvider: tp})
+	ShutdownTracerProviders()
+
+	if !assert.NotNil(t, received) {
+		return
+	}
+	resourceSpans := received["resourceSpans"].([]interface{})
+	if !assert.Len(t, resourceSpans, 1) {
+		return
+	}
+	scopeSpans := resourceSpans[0].(map[string]interface{})["scopeSpans"].([]interface{})
+	spans := scopeSpans[0].(map[string]interface{})["spans"].([]interface{})
+	if !assert.Len(t, spans, 1) {
+		return
+	}
+	span := spans[0].(map[string]interface{})
+	assert.Equal(t, "sakuracloud_switch.read", span["name"])
+	assert.Len(t, span["traceId"], 32)
+	assert.Len(t, span["spanId"], 16)
+}
+
+func TestOTLPTracesRequest_schema(t *testing.T) {
+	start := time.Date(2021, 1, 2, 3, 4, 5, 6, time.UTC)
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("1112131415161718")
+	parentID, _ := trace.SpanIDFromHex("2122232425262728")
+
+	spans := []*export.SpanData{
+		{
+			SpanContext:  trace.SpanContext{TraceID: traceID, SpanID: spanID},
+			ParentSpanID: parentID,
+			SpanKind:     trace.SpanKindClient,
+			Name:         "sakuracloud_server.create",
+			StartTime:    start,
+			EndTime:      start.Add(time.Second),
+			Attributes: []label.KeyValue{
+				label.String("terraform.operation", "create"),
+				label.Bool("bool", true),
+				label.Int64("int", 10),
+				label.Float64("float", 1.5),
+			},
+			MessageEvents: []export.Event{
+				{Name: "event", Time: start, Attributes: []label.KeyValue{label.Int("count", 1)}},
+			},
+			StatusCode:             codes.Error,
+			StatusMessage:          "failed",
+			Resource:               resource.NewWithAttributes(label.String("service.name", "terraform-provider-sakuracloud")),
+			InstrumentationLibrary: instrumentation.Library{Name: "sakuracloud", Version: "2.0.0"},
+		},
+		{
+			SpanContext: trace.SpanContext{TraceID: traceID, SpanID: parentID},
+			SpanKind:    trace.SpanKindInternal,
+			Name:        "sakuracloud_server.apply",
+			StartTime:   start,
+			EndTime:     start.Add(2 * time.Second),
+			StatusCode:  codes.Ok,
+			Resource:    resource.NewWithAttributes(label.String("service.name", "terraform-provider-sakuracloud")),
+		},
+	}
+
+	body, err := json.Marshal(otlpTracesRequest(spans))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var request struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []map[string]interface{} `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Scope map[string]interface{}   `json:"scope"`
+				Spans []map[string]interface{} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if !assert.NoError(t, json.Unmarshal(body, &request)) {
+		return
+	}
+	if !assert.Len(t, request.ResourceSpans, 1) || !assert.Len(t, request.ResourceSpans[0].ScopeSpans, 1) {
+		return
+	}
+	testValidateOTLPAttributes(t, request.ResourceSpans[0].Resource.Attributes)
+	assert.Equal(t, "sakuracloud", request.ResourceSpans[0].ScopeSpans[0].Scope["name"])
+
+	spansJSON := request.ResourceSpans[0].ScopeSpans[0].Spans
+	if !assert.Len(t, spansJSON, 2) {
+		return
+	}
+	for _, span := range spansJSON {
+		// trace_idとspan_idはbase64ではなく小文字の16進数文字列であること
+		assert.Regexp(t, `^[0-9a-f]{32}$`, span["traceId"])
+		assert.Regexp(t, `^[0-9a-f]{16}$`, span["spanId"])
+		if parent, ok := span["parentSpanId"]; ok {
+			assert.Regexp(t, `^[0-9a-f]{16}$`, parent)
+		}
+		// 64bit整数は文字列であること
+		assert.Regexp(t, `^[0-9]+$`, span["startTimeUnixNano"])
+		assert.Regexp(t, `^[0-9]+$`, span["endTimeUnixNano"])
+		// 列挙型は数値であること
+		kind, ok := span["kind"].(float64)
+		assert.True(t, ok && kind >= 0 && kind <= 5, "kind: %v", span["kind"])
+		status := span["status"].(map[string]interface{})
+		code, ok := status["code"].(float64)
+		assert.True(t, ok && code >= 0 && code <= 2, "status.code: %v", status["code"])
+
+		var attrs []map[string]interface{}
+		for _, attr := range span["attributes"].([]interface{}) {
+			attrs = append(attrs, attr.(map[string]interface{}))
+		}
+		testValidateOTLPAttributes(t, attrs)
+	}
+
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", spansJSON[0]["traceId"])
+	assert.Equal(t, "1112131415161718", spansJSON[0]["spanId"])
+	assert.Equal(t, "2122232425262728", spansJSON[0]["parentSpanId"])
+	assert.Equal(t, strconv.FormatInt(start.UnixNano(), 10), spansJSON[0]["startTimeUnixNano"])
+	assert.EqualValues(t, 3, spansJSON[0]["kind"])                                                           // SPAN_KIND_CLIENT
+	assert.Equal(t, map[string]interface{}{"code": float64(2), "message": "failed"}, spansJSON[0]["status"]) // STATUS_CODE_ERROR
+	assert.Len(t, spansJSON[0]["events"], 1)
+
+	assert.NotContains(t, spansJSON[1], "parentSpanId")
+	assert.EqualValues(t, 1, spansJSON[1]["kind"])                                      // SPAN_KIND_INTERNAL
+	assert.Equal(t, map[string]interface{}{"code": float64(1)}, spansJSON[1]["status"]) // STATUS_CODE_OK
+}
+
+// testValidateOTLPAttributes OTLPのKeyValueのJSON表現であることを検証する
+func testValidateOTLPAttributes(t *testing.T, attrs []map[string]interface{}) {
+	for _, attr := range attrs {
+		assert.IsType(t, "", attr["key"])
+		value := attr["value"].(map[string]interface{})
+		if !assert.Len(t, value, 1, attr["key"]) {
+			continue
+		}
+		for k, v := range value {
+			switch k {
+			case "stringValue":
+				assert.IsType(t, "", v, attr["key"])
+			case "boolValue":
+				assert.IsType(t, true, v, attr["key"])
+			case "intValue":
+				assert.Regexp(t, `^-?[0-9]+$`, v, attr["key"])
+			case "doubleValue":
+				assert.IsType(t, float64(0), v, attr["key"])
+			default:
+				t.Errorf("attribute %v has unexpected value type: %s", attr["key"], k)
+			}
+		}
+	}
+}
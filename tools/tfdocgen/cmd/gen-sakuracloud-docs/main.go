@@ -100,6 +100,10 @@ var definitions = map[string]definition{
 		displayName: "Archive",
 		category:    CategoryStorage,
 	},
+	"sakuracloud_archives": {
+		displayName: "Archives",
+		category:    CategoryStorage,
+	},
 	"sakuracloud_archive_share": {
 		displayName: "Archive",
 		category:    CategoryStorage,
@@ -124,18 +128,54 @@ var definitions = map[string]definition{
 		displayName: "Container Registry",
 		category:    CategoryLab,
 	},
+	"sakuracloud_cost_estimate": {
+		displayName: "Cost Estimate",
+		category:    CategoryMisc,
+	},
 	"sakuracloud_database": {
 		displayName: "Database",
 		category:    CategoryAppliance,
 	},
+	"sakuracloud_databases": {
+		displayName: "Databases",
+		category:    CategoryAppliance,
+	},
+	"sakuracloud_database_backup": {
+		displayName: "Database Backup",
+		category:    CategoryAppliance,
+	},
+	"sakuracloud_database_backup_history": {
+		displayName: "Database Backup History",
+		category:    CategoryAppliance,
+	},
+	"sakuracloud_database_monitor": {
+		displayName: "Database Monitor",
+		category:    CategoryAppliance,
+	},
 	"sakuracloud_database_read_replica": {
 		displayName: "Database Read Replica",
 		category:    CategoryAppliance,
 	},
+	"sakuracloud_database_restore": {
+		displayName: "Database Restore",
+		category:    CategoryAppliance,
+	},
 	"sakuracloud_disk": {
 		displayName: "Disk",
 		category:    CategoryStorage,
 	},
+	"sakuracloud_disks": {
+		displayName: "Disks",
+		category:    CategoryStorage,
+	},
+	"sakuracloud_disk_plans": {
+		displayName: "Disk Plans",
+		category:    CategoryStorage,
+	},
+	"sakuracloud_disk_edit": {
+		displayName: "Disk Edit",
+		category:    CategoryStorage,
+	},
 	"sakuracloud_dns": {
 		displayName: "DNS",
 		category:    CategoryGlobal,
@@ -156,6 +196,26 @@ var definitions = map[string]definition{
 		displayName: "Switch+Router",
 		category:    CategoryNetworking,
 	},
+	"sakuracloud_internets": {
+		displayName: "Switch+Routers",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_internet_plans": {
+		displayName: "Switch+Router Plans",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_internet_monitor": {
+		displayName: "Switch+Router Monitor",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_ip_address_allocation": {
+		displayName: "IP Address Allocation",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_internet_ptr_records": {
+		displayName: "Internet PTR Records",
+		category:    CategoryNetworking,
+	},
 	"sakuracloud_ipv4_ptr": {
 		displayName: "IPv4 PTR",
 		category:    CategoryNetworking,
@@ -168,6 +228,14 @@ var definitions = map[string]definition{
 		displayName: "Local Router",
 		category:    CategoryNetworking,
 	},
+	"sakuracloud_local_router_monitor": {
+		displayName: "Local Router Monitor",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_local_router_peer": {
+		displayName: "Local Router Peer",
+		category:    CategoryNetworking,
+	},
 	"sakuracloud_mobile_gateway": {
 		displayName: "Mobile Gateway",
 		category:    CategorySecureMobile,
@@ -176,6 +244,10 @@ var definitions = map[string]definition{
 		displayName: "NFS",
 		category:    CategoryAppliance,
 	},
+	"sakuracloud_nfs_monitor": {
+		displayName: "NFS Monitor",
+		category:    CategoryAppliance,
+	},
 	"sakuracloud_note": {
 		displayName: "Note",
 		category:    CategoryMisc,
@@ -184,6 +256,14 @@ var definitions = map[string]definition{
 		displayName: "Packet Filter",
 		category:    CategoryNetworking,
 	},
+	"sakuracloud_packet_filter_attachment": {
+		displayName: "Packet Filter Attachment",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_packet_filter_evaluate": {
+		displayName: "Packet Filter Evaluate",
+		category:    CategoryNetworking,
+	},
 	"sakuracloud_packet_filter_rules": {
 		displayName: "Packet Filter Rules",
 		category:    CategoryNetworking,
@@ -192,6 +272,10 @@ var definitions = map[string]definition{
 		displayName: "Private Host",
 		category:    CategoryCompute,
 	},
+	"sakuracloud_private_host_plans": {
+		displayName: "Private Host Plans",
+		category:    CategoryCompute,
+	},
 	"sakuracloud_proxylb": {
 		displayName: "ProxyLB",
 		category:    CategoryGlobal,
@@ -204,10 +288,26 @@ var definitions = map[string]definition{
 		displayName: "Server",
 		category:    CategoryCompute,
 	},
+	"sakuracloud_server_network_interface": {
+		displayName: "Server Network Interface",
+		category:    CategoryCompute,
+	},
+	"sakuracloud_servers": {
+		displayName: "Servers",
+		category:    CategoryCompute,
+	},
+	"sakuracloud_server_plans": {
+		displayName: "Server Plans",
+		category:    CategoryCompute,
+	},
 	"sakuracloud_server_vnc_info": {
 		displayName: "Server VNC Information",
 		category:    CategoryCompute,
 	},
+	"sakuracloud_service_class": {
+		displayName: "Service Class",
+		category:    CategoryMisc,
+	},
 	"sakuracloud_sim": {
 		displayName: "SIM",
 		category:    CategorySecureMobile,
@@ -232,6 +332,18 @@ var definitions = map[string]definition{
 		displayName: "Switch",
 		category:    CategoryNetworking,
 	},
+	"sakuracloud_switch_bridge_connection": {
+		displayName: "Switch Bridge Connection",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_switch_topology": {
+		displayName: "Switch Topology",
+		category:    CategoryNetworking,
+	},
+	"sakuracloud_switches": {
+		displayName: "Switches",
+		category:    CategoryNetworking,
+	},
 	"sakuracloud_vpc_router": {
 		displayName: "VPC Router",
 		category:    CategoryAppliance,